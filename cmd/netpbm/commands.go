@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	netpbm "Netbpm"
+)
+
+// commands liste les sous-commandes, dans l'ordre de l'aide.
+var commands = []command{
+	{name: "info", args: "[file ...]", summary: "print the format, size, maxval and comments of each file", run: runInfo},
+	{name: "run", args: "[input] pipeline [output]", summary: "apply a pipeline such as 'invert | rotate 90 | topgm'", run: runPipeline},
+	{name: "convert", args: "[input [output]]", summary: "convert to another format (-format P1..P7) or maxval", setup: setupConvert},
+	{name: "invert", args: "[input [output]]", summary: "invert the colors", setup: op(netpbm.Invert()), keep: true},
+	{name: "flip", args: "[input [output]]", summary: "mirror left to right", setup: op(netpbm.Flip()), keep: true},
+	{name: "flop", args: "[input [output]]", summary: "mirror top to bottom", setup: op(netpbm.Flop()), keep: true},
+	{name: "rotate", args: "[input [output]]", summary: "rotate clockwise by -angle degrees", setup: setupRotate, keep: true},
+	{name: "crop", args: "[input [output]]", summary: "keep the rectangle -rect x,y,width,height", setup: setupCrop, keep: true},
+	{name: "topbm", args: "[input [output]]", summary: "convert to black and white (PBM)", setup: op(netpbm.ToPBM())},
+	{name: "topgm", args: "[input [output]]", summary: "convert to grayscale (PGM)", setup: setupToPGM},
+	{name: "toppm", args: "[input [output]]", summary: "convert to color (PPM)", setup: setupToPPM},
+	{name: "comment", args: "[input [output]]", summary: "add, set or clear header comments", setup: setupComment, keep: true},
+	{name: "draw", args: "[input [output]]", summary: "draw lines and shapes on the image", setup: setupDraw},
+}
+
+// op renvoie la fonction setup d'une sous-commande sans option qui applique
+// simplement o.
+func op(o netpbm.Op) func(fs *flag.FlagSet) transform {
+	return func(fs *flag.FlagSet) transform {
+		return transform(o)
+	}
+}
+
+func setupConvert(fs *flag.FlagSet) transform {
+	format := fs.String("format", "", "target magic number, P1 to P7 (default: keep the input format)")
+	maxValue := fs.Int("maxval", 0, "target maxval, 1 to 65535 (default: keep the input maxval)")
+	return func(img netpbm.Image) (netpbm.Image, error) {
+		magicNumber := strings.ToUpper(*format)
+		if magicNumber == "" {
+			magicNumber = img.Format()
+		}
+		return netpbm.Convert(magicNumber, *maxValue)(img)
+	}
+}
+
+func setupRotate(fs *flag.FlagSet) transform {
+	angle := fs.Float64("angle", 90, "clockwise angle in degrees, negative for counterclockwise; multiples of 90 are exact")
+	background := fs.String("background", "0", "color `r,g,b` (or a gray level) of the corners added by other angles")
+	interpolation := fs.String("interpolation", "bilinear", "interpolation for other angles: nearest, bilinear or bicubic")
+	return func(img netpbm.Image) (netpbm.Image, error) {
+		if math.IsNaN(*angle) || math.IsInf(*angle, 0) {
+			return nil, fmt.Errorf("-angle: invalid angle %v", *angle)
+		}
+		c, err := netpbm.ParseColor(*background)
+		if err != nil {
+			return nil, fmt.Errorf("-background: %w", err)
+		}
+		interp, err := netpbm.ParseInterpolation(*interpolation)
+		if err != nil {
+			return nil, fmt.Errorf("-interpolation: %w", err)
+		}
+		return netpbm.RotateAngle(*angle, c, interp)(img)
+	}
+}
+
+func setupCrop(fs *flag.FlagSet) transform {
+	rect := fs.String("rect", "", "rectangle to keep, as `x,y,width,height`")
+	return func(img netpbm.Image) (netpbm.Image, error) {
+		n, err := netpbm.ParseInts(*rect)
+		if err == nil && len(n) != 4 {
+			err = fmt.Errorf("%q: expected x,y,width,height", *rect)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("-rect: %w", err)
+		}
+		return netpbm.Crop(image.Rect(n[0], n[1], n[0]+n[2], n[1]+n[3]))(img)
+	}
+}
+
+func setupToPGM(fs *flag.FlagSet) transform {
+	maxValue := fs.Int("maxval", 0, "maxval of a grayscale image made from a PBM image (default 255)")
+	return func(img netpbm.Image) (netpbm.Image, error) {
+		return netpbm.ToPGM(*maxValue)(img)
+	}
+}
+
+func setupToPPM(fs *flag.FlagSet) transform {
+	maxValue := fs.Int("maxval", 0, "maxval of a color image made from a PBM image (default 255)")
+	return func(img netpbm.Image) (netpbm.Image, error) {
+		return netpbm.ToPPM(*maxValue)(img)
+	}
+}
+
+// commented est implémentée par les images qui conservent les commentaires
+// de leur en-tête.
+type commented interface {
+	Comments() []string
+	SetComments(comments []string)
+	SetMetadata(key, value string)
+}
+
+func setupComment(fs *flag.FlagSet) transform {
+	var add, set []string
+	clear := fs.Bool("clear", false, "remove the existing comments first")
+	fs.Func("add", "add a comment (repeatable)", func(text string) error {
+		add = append(add, text)
+		return nil
+	})
+	fs.Func("set", "add or replace a key=value comment (repeatable)", func(kv string) error {
+		if !strings.Contains(kv, "=") {
+			return errors.New("expected key=value")
+		}
+		set = append(set, kv)
+		return nil
+	})
+	return func(img netpbm.Image) (netpbm.Image, error) {
+		c, ok := img.(commented)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s images have no comments", netpbm.ErrUnsupported, img.Format())
+		}
+		comments := c.Comments()
+		if *clear {
+			comments = nil
+		}
+		c.SetComments(append(comments, add...))
+		for _, kv := range set {
+			key, value, _ := strings.Cut(kv, "=")
+			c.SetMetadata(key, value)
+		}
+		return img, nil
+	}
+}
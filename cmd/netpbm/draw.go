@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	netpbm "Netbpm"
+)
+
+// shapeFlag est une option de draw dont chaque occurrence ajoute une figure,
+// de la couleur courante, au pipeline ops. Les figures sont donc tracées dans
+// l'ordre de la ligne de commande.
+type shapeFlag struct {
+	shape string
+	ops   *netpbm.Pipeline
+	color *netpbm.Pixel
+}
+
+func (f *shapeFlag) String() string { return "" }
+
+func (f *shapeFlag) Set(s string) error {
+	values, err := netpbm.ParseInts(s)
+	if err != nil {
+		return err
+	}
+	op, err := netpbm.Draw(f.shape, values, *f.color)
+	if err != nil {
+		return err
+	}
+	*f.ops = append(*f.ops, op)
+	return nil
+}
+
+func setupDraw(fs *flag.FlagSet) transform {
+	var ops netpbm.Pipeline
+	color := netpbm.Pixel{}
+	fs.Func("color", "color `r,g,b` (or a gray level) of the following shapes, black by default", func(s string) error {
+		c, err := netpbm.ParseColor(s)
+		if err != nil {
+			return err
+		}
+		color = c
+		return nil
+	})
+	for _, f := range []struct {
+		shape string
+		usage string
+	}{
+		{"line", "draw a line from `x1,y1,x2,y2`"},
+		{"rect", "draw a rectangle `x,y,width,height`"},
+		{"fillrect", "fill a rectangle `x,y,width,height`"},
+		{"circle", "draw a circle `x,y,radius`"},
+		{"fillcircle", "fill a circle `x,y,radius`"},
+		{"triangle", "draw a triangle `x1,y1,x2,y2,x3,y3`"},
+		{"filltriangle", "fill a triangle `x1,y1,x2,y2,x3,y3`"},
+		{"polygon", "draw a polygon `x1,y1,x2,y2,...`"},
+		{"fillpolygon", "fill a polygon `x1,y1,x2,y2,...`"},
+		{"koch", "draw a Koch snowflake `x,y,radius,depth`"},
+	} {
+		fs.Var(&shapeFlag{shape: f.shape, ops: &ops, color: &color}, f.shape, f.usage)
+	}
+
+	return func(img netpbm.Image) (netpbm.Image, error) {
+		if len(ops) == 0 {
+			return nil, errors.New("nothing to draw")
+		}
+		return ops.Apply(img)
+	}
+}
@@ -0,0 +1,327 @@
+// Commande netpbm : lit, transforme et écrit des images Netpbm, comme les
+// outils pnm* classiques.
+//
+//	netpbm <commande> [options] [entrée [sortie]]
+//
+// L'entrée et la sortie sont des noms de fichiers ; absentes ou égales à "-",
+// elles désignent l'entrée et la sortie standard, ce qui permet d'enchaîner
+// les commandes dans un tube :
+//
+//	netpbm topgm photo.ppm | netpbm invert | netpbm rotate -angle 180 - out.pgm
+//
+// La commande run applique plusieurs opérations à la suite sur l'image en
+// mémoire :
+//
+//	netpbm run photo.ppm 'invert | rotate 90 | topgm' out.pgm
+//
+// Pour toutes les commandes, l'image est convertie selon l'extension du
+// fichier de sortie (.pbm, .pgm, .ppm ou .pam). Sans extension connue, les
+// commandes qui ne changent pas le format de l'image, comme invert ou rotate,
+// l'écrivent dans le format de l'entrée.
+//
+// Les commandes de transformation traitent une à une toutes les images d'un
+// flux, comme celui produit par ffmpeg -f image2pipe -vcodec ppm.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	netpbm "Netbpm"
+)
+
+// transform modifie ou remplace une image ; elle est appliquée à chaque image
+// de l'entrée.
+type transform func(img netpbm.Image) (netpbm.Image, error)
+
+// command décrit une sous-commande. setup déclare ses options dans fs et
+// renvoie la transformation à appliquer une fois les options lues ; run
+// remplace tout le traitement pour les commandes qui n'écrivent pas d'image.
+type command struct {
+	name    string
+	args    string // Arguments qui suivent les options, pour l'aide
+	summary string
+	setup   func(fs *flag.FlagSet) transform
+	run     func(fs *flag.FlagSet, env *env) error
+	// keep indique une transformation qui ne change pas le format de
+	// l'image : une image P7, convertie pour être transformée, est
+	// réécrite au format P7.
+	keep bool
+}
+
+// env regroupe les flux de la commande, remplacés par des tampons dans les tests.
+type env struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+// errUsage signale une erreur dans la ligne de commande, déjà expliquée sur
+// la sortie d'erreur.
+var errUsage = errors.New("usage")
+
+func main() {
+	err := run(os.Args[1:], &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr})
+	if err == errUsage {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "netpbm:", err)
+		os.Exit(1)
+	}
+}
+
+// run exécute la sous-commande désignée par args[0].
+func run(args []string, env *env) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		usage(env.stderr)
+		if len(args) == 0 {
+			return errUsage
+		}
+		return nil
+	}
+
+	cmd := lookup(args[0])
+	if cmd == nil {
+		fmt.Fprintf(env.stderr, "netpbm: unknown command %q\n", args[0])
+		usage(env.stderr)
+		return errUsage
+	}
+
+	fs := flag.NewFlagSet("netpbm "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(env.stderr, "usage: netpbm %s [options] %s\n%s\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+	var t transform
+	if cmd.setup != nil {
+		t = cmd.setup(fs)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+
+	if cmd.run != nil {
+		return cmd.run(fs, env)
+	}
+	if fs.NArg() > 2 {
+		fs.Usage()
+		return errUsage
+	}
+	if err := process(fs.Arg(0), fs.Arg(1), cmd.keep, env, t); err != nil {
+		return fmt.Errorf("%s: %w", cmd.name, err)
+	}
+	return nil
+}
+
+// lookup renvoie la sous-commande nommée name, ou nil si elle n'existe pas.
+func lookup(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// usage écrit la liste des sous-commandes dans w.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: netpbm <command> [options] [input [output]]")
+	fmt.Fprintln(w, "\nInput and output default to stdin and stdout; \"-\" also means stdin or stdout.")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\nRun 'netpbm <command> -h' for the options of a command.")
+}
+
+// process applique t à chaque image lue depuis input et écrit le résultat dans
+// output, converti selon l'extension du fichier output. Si keep est vrai,
+// l'image garde sinon le format de l'image lue, même si t l'a convertie : c'est
+// le cas d'une image P7, que Decode renvoie sous forme d'image PBM, PGM ou PPM.
+// Le fichier de sortie n'est créé qu'après la lecture de la première image, si
+// bien qu'une entrée illisible ne l'écrase pas.
+func process(input, output string, keep bool, env *env, t transform) error {
+	in, err := openInput(input, env)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var out io.WriteCloser
+	br := bufio.NewReader(in)
+	sr := netpbm.NewStreamReader(br)
+	for n := 0; ; n++ {
+		format := peekFormat(br)
+		img, err := sr.Next()
+		if err == io.EOF {
+			if n == 0 {
+				return errors.New("no image in input")
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if img, err = t(img); err != nil {
+			return err
+		}
+		if !keep {
+			format = img.Format()
+		}
+		if magicNumber := outputFormat(output, format); magicNumber != img.Format() {
+			if img, err = netpbm.Convert(magicNumber, 0)(img); err != nil {
+				return err
+			}
+		}
+
+		if out == nil {
+			if out, err = createOutput(output, env); err != nil {
+				return err
+			}
+			defer out.Close()
+		}
+		if err := img.Encode(out); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// peekFormat renvoie le numéro magique de la prochaine image de br, sans le
+// consommer ; seuls les blancs qui le précèdent sont lus.
+func peekFormat(br *bufio.Reader) string {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return ""
+		}
+		if b != ' ' && b != '\t' && b != '\n' && b != '\r' && b != '\v' && b != '\f' {
+			br.UnreadByte()
+			break
+		}
+	}
+	magic, _ := br.Peek(2)
+	return string(magic)
+}
+
+// openInput ouvre le fichier name, ou l'entrée standard si name est vide ou "-".
+func openInput(name string, env *env) (io.ReadCloser, error) {
+	if name == "" || name == "-" {
+		return io.NopCloser(env.stdin), nil
+	}
+	return os.Open(name)
+}
+
+// createOutput crée le fichier name, ou renvoie la sortie standard si name
+// est vide ou "-".
+func createOutput(name string, env *env) (io.WriteCloser, error) {
+	if name == "" || name == "-" {
+		return nopWriteCloser{env.stdout}, nil
+	}
+	return os.Create(name)
+}
+
+// nopWriteCloser ajoute une méthode Close sans effet à un io.Writer, pour ne
+// pas fermer la sortie standard.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// runInfo affiche l'en-tête de chaque fichier, ou de l'image lue sur l'entrée
+// standard, sans lire les pixels.
+func runInfo(fs *flag.FlagSet, env *env) error {
+	names := fs.Args()
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	failed := false
+	for _, name := range names {
+		header, err := readHeader(name, env)
+		if err != nil {
+			fmt.Fprintf(env.stderr, "netpbm: info: %s: %v\n", name, err)
+			failed = true
+			continue
+		}
+		fmt.Fprintf(env.stdout, "%s: %s %dx%d maxval %d", name, header.Format, header.Width, header.Height, header.MaxValue)
+		if header.Format == "P7" {
+			fmt.Fprintf(env.stdout, " depth %d", header.Depth)
+			if header.TupleType != "" {
+				fmt.Fprintf(env.stdout, " %s", header.TupleType)
+			}
+		}
+		fmt.Fprintln(env.stdout)
+		for _, comment := range header.Comments {
+			fmt.Fprintf(env.stdout, "  # %s\n", strings.ReplaceAll(comment, "\n", "\n  # "))
+		}
+	}
+	if failed {
+		return errors.New("info: some files could not be read")
+	}
+	return nil
+}
+
+// readHeader lit l'en-tête du fichier name, ou de l'entrée standard pour "-".
+func readHeader(name string, env *env) (*netpbm.Header, error) {
+	if name == "-" {
+		return netpbm.ReadHeader(env.stdin)
+	}
+	return netpbm.ReadHeaderFile(name)
+}
+
+// runPipeline applique le pipeline donné en argument à chaque image de
+// l'entrée. Ses arguments sont [entrée] pipeline [sortie].
+func runPipeline(fs *flag.FlagSet, env *env) error {
+	var input, pipeline, output string
+	switch args := fs.Args(); len(args) {
+	case 1:
+		pipeline = args[0]
+	case 2:
+		input, pipeline = args[0], args[1]
+	case 3:
+		input, pipeline, output = args[0], args[1], args[2]
+	default:
+		fs.Usage()
+		return errUsage
+	}
+	p, err := netpbm.ParsePipeline(pipeline)
+	if err != nil {
+		return fmt.Errorf("run: %w", err)
+	}
+	if err := process(input, output, false, env, transform(p.Apply)); err != nil {
+		return fmt.Errorf("run: %w", err)
+	}
+	return nil
+}
+
+// outputFormat renvoie le numéro magique qui correspond à l'extension du
+// fichier name, en texte ou en binaire comme format, ou format lui-même si
+// l'extension est inconnue ou correspond déjà.
+func outputFormat(name, format string) string {
+	ascii := format == "P1" || format == "P2" || format == "P3"
+	formats := map[string][2]string{
+		".pbm": {"P4", "P1"},
+		".pgm": {"P5", "P2"},
+		".ppm": {"P6", "P3"},
+		".pam": {"P7", "P7"},
+	}
+	f, ok := formats[strings.ToLower(filepath.Ext(name))]
+	if !ok || format == f[0] || format == f[1] {
+		return format
+	}
+	if ascii {
+		return f[1]
+	}
+	return f[0]
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCommand exécute la commande args avec stdin pour entrée standard et
+// renvoie la sortie standard.
+func runCommand(t *testing.T, stdin string, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	if err := run(args, &env{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr}); err != nil {
+		t.Fatalf("netpbm %s: %v\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestTransforms(t *testing.T) {
+	gray := "P2\n3 2\n9\n0 1 2\n3 4 5\n"
+	tests := []struct {
+		args []string
+		in   string
+		want string
+	}{
+		{[]string{"invert"}, gray, "P2\n3 2\n9\n9 8 7 \n6 5 4 \n"},
+		{[]string{"flip"}, gray, "P2\n3 2\n9\n2 1 0 \n5 4 3 \n"},
+		{[]string{"flop"}, gray, "P2\n3 2\n9\n3 4 5 \n0 1 2 \n"},
+		{[]string{"rotate"}, gray, "P2\n2 3\n9\n3 0 \n4 1 \n5 2 \n"},
+		{[]string{"rotate", "-angle", "-90"}, gray, "P2\n2 3\n9\n2 5 \n1 4 \n0 3 \n"},
+		{[]string{"rotate", "-angle", "45", "-background", "9", "-interpolation", "nearest"}, "P2 2 2 9 0 0 0 0\n", "P2\n3 3\n9\n9 0 9 \n0 0 0 \n9 0 9 \n"},
+		{[]string{"run", "rotate 45 9 nearest"}, "P2 2 2 9 0 0 0 0\n", "P2\n3 3\n9\n9 0 9 \n0 0 0 \n9 0 9 \n"},
+		{[]string{"crop", "-rect", "1,0,2,2"}, gray, "P2\n2 2\n9\n1 2 \n4 5 \n"},
+		{[]string{"convert", "-format", "P5"}, gray, "P5\n3 2\n9\n\x00\x01\x02\x03\x04\x05"},
+		{[]string{"convert", "-maxval", "255"}, "P2 2 1 1 0 1\n", "P2\n2 1\n255\n0 255 \n"},
+		{[]string{"convert", "-format", "P7"}, "P5 2 1 255\n\x00\xff", "P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 255\nTUPLTYPE GRAYSCALE\nENDHDR\n\x00\xff"},
+		{[]string{"topgm"}, "P3 2 1 255 30 60 90 255 255 255\n", "P2\n2 1\n255\n60 255 \n"},
+		{[]string{"topgm"}, "P4 2 1\n\x80", "P5\n2 1\n255\n\x00\xff"},
+		{[]string{"toppm"}, "P5 1 1 7\n\x03", "P6\n1 1\n7\n\x03\x03\x03"},
+		{[]string{"topbm"}, "P2 2 1 255 0 255\n", "P1\n2 1\n1 0 \n"},
+		{[]string{"topbm"}, "P2 2 1 1 0 1\n", "P1\n2 1\n1 0 \n"},
+		// Une image binaire reste binaire
+		{[]string{"topgm"}, "P6 1 1 255\n\xff\xff\xff", "P5\n1 1\n255\n\xff"},
+		{[]string{"topbm"}, "P5 2 1 255\n\x00\xff", "P4\n2 1\n\x80"},
+		{[]string{"topgm"}, "P7\nWIDTH 1\nHEIGHT 1\nDEPTH 3\nMAXVAL 255\nTUPLTYPE RGB\nENDHDR\n\xff\xff\xff", "P5\n1 1\n255\n\xff"},
+		{[]string{"comment", "-add", "hello", "-set", "gamma=2.2"}, "P5\n# old\n1 1 255\n\x00", "P5\n# old\n# hello\n# gamma=2.2\n1 1\n255\n\x00"},
+		{[]string{"comment", "-clear"}, "P5\n# old\n1 1 255\n\x00", "P5\n1 1\n255\n\x00"},
+		{[]string{"draw", "-color", "9,0,0", "-line", "0,0,2,0"}, "P3 3 1 9 0 0 0 0 0 0 0 0 0\n", "P3\n3 1\n9\n9 0 0\n9 0 0\n9 0 0\n"},
+		{[]string{"run", "invert | flip | crop 0,0,2,1"}, gray, "P2\n2 1\n9\n7 8 \n"},
+		{[]string{"run", "topbm | draw line 0,0,1,0 9,0,0"}, "P1 2 1 0 0\n", "P3\n2 1\n255\n9 0 0\n9 0 0\n"},
+		// Une image P7, convertie pour être transformée, reste une image P7
+		{[]string{"invert"}, "P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 255\nTUPLTYPE GRAYSCALE\nENDHDR\n\x00\x10",
+			"P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 255\nTUPLTYPE GRAYSCALE\nENDHDR\n\xff\xef"},
+		// Chaque image d'un flux est traitée
+		{[]string{"invert"}, "P5 1 1 255\n\x00P4 8 1\n\x0f", "P5\n1 1\n255\n\xffP4\n8 1\n\xf0"},
+	}
+	for _, test := range tests {
+		got := runCommand(t, test.in, test.args...)
+		if got != test.want {
+			t.Errorf("netpbm %s: got %q, want %q", strings.Join(test.args, " "), got, test.want)
+		}
+	}
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "in.pgm")
+	output := filepath.Join(dir, "out.pgm")
+	if err := os.WriteFile(input, []byte("P2\n# CREATOR: test\n2 1\n3\n0 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if out := runCommand(t, "", "invert", input, output); out != "" {
+		t.Errorf("Unexpected output on stdout: %q", out)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "P2\n# CREATOR: test\n2 1\n3\n3 0 \n" {
+		t.Errorf("Wrong output file: %q", data)
+	}
+
+	info := runCommand(t, "", "info", input)
+	if info != input+": P2 2x1 maxval 3\n  # CREATOR: test\n" {
+		t.Errorf("Wrong info: %q", info)
+	}
+
+	// L'image est écrite dans le format qui correspond à l'extension
+	output = filepath.Join(dir, "out.pam")
+	runCommand(t, "", "invert", input, output)
+	if data, err = os.ReadFile(output); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 3\nTUPLTYPE GRAYSCALE\nENDHDR\n\x03\x00" {
+		t.Errorf("Wrong PAM output file: %q", data)
+	}
+
+	// run convertit l'image selon l'extension du fichier de sortie
+	output = filepath.Join(dir, "out.ppm")
+	runCommand(t, "", "run", input, "invert | rotate 90", output)
+	if data, err = os.ReadFile(output); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "P3\n# CREATOR: test\n1 2\n3\n3 3 3\n0 0 0\n" {
+		t.Errorf("Wrong run output file: %q", data)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"unknown"},
+		{"invert", "a", "b", "c"},
+		{"rotate", "-angle", "NaN"},
+		{"rotate", "-angle", "45", "-interpolation", "cubic"},
+		{"rotate", "-angle", "45", "-background", "1,2"},
+		{"run", "rotate 45 0 cubic"},
+		{"convert", "-format", "P9"},
+		{"draw", "-line", "1,2,3"},
+		{"crop", "-rect", "10,10,1,1"},
+		{"run"},
+		{"run", "invert | | flip"},
+		{"invert", filepath.Join(t.TempDir(), "missing.pgm")},
+	}
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		err := run(args, &env{stdin: strings.NewReader("P2 2 1 9 0 9\n"), stdout: &stdout, stderr: &stderr})
+		if err == nil {
+			t.Errorf("netpbm %s: expected an error", strings.Join(args, " "))
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("netpbm %s: unexpected output %q", strings.Join(args, " "), stdout.String())
+		}
+	}
+
+	// Une entrée vide est une erreur
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"invert"}, &env{stdin: strings.NewReader(""), stdout: &stdout, stderr: &stderr}); err == nil {
+		t.Error("Expected an error for an empty input")
+	}
+
+	// Une image PAM avec opacité ne peut pas être inversée sans perdre son opacité
+	alpha := "P7\nWIDTH 1\nHEIGHT 1\nDEPTH 4\nMAXVAL 255\nTUPLTYPE RGB_ALPHA\nENDHDR\n\xff\x00\x00\x80"
+	stdout.Reset()
+	if err := run([]string{"invert"}, &env{stdin: strings.NewReader(alpha), stdout: &stdout, stderr: &stderr}); err == nil {
+		t.Error("Expected an error for an alpha PAM input")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Unexpected output %q for an alpha PAM input", stdout.String())
+	}
+}
@@ -0,0 +1,179 @@
+// Package netpbm lit, modifie et écrit les images Netpbm : PBM, PGM, PPM, PAM
+// et PFM. Les types PBM, PGM, PPM et PAM se convertissent les uns dans les
+// autres, et Decode lit une image sans connaître son format à l'avance.
+// Importer ce paquet enregistre aussi les formats "pbm", "pgm", "ppm" et "pam"
+// auprès de image.Decode et image.DecodeConfig.
+package netpbm
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Image est l'interface commune aux images PBM, PGM, PPM et PAM.
+type Image interface {
+	image.Image
+
+	// Size renvoie la largeur et la hauteur de l'image.
+	Size() (int, int)
+	// Format renvoie le numéro magique de l'image, de P1 à P7.
+	Format() string
+	// MaxValue renvoie la valeur maximale d'un échantillon.
+	MaxValue() int
+	// Samples renvoie les échantillons du pixel (x, y) : un seul pour PBM et
+	// PGM, trois (rouge, vert, bleu) pour PPM, autant que la profondeur pour PAM.
+	Samples(x, y int) []uint16
+	// Encode écrit l'image dans w dans son propre format.
+	Encode(w io.Writer) error
+}
+
+var (
+	_ Image = (*PBM)(nil)
+	_ Image = (*PGM)(nil)
+	_ Image = (*PPM)(nil)
+	_ Image = (*PAM)(nil)
+)
+
+// FromImage convertit une image quelconque dans le format Netpbm désigné par
+// magicNumber. maxValue est ignorée pour les formats PBM.
+func FromImage(img image.Image, magicNumber string, maxValue int) (Image, error) {
+	switch magicNumber {
+	case "P1", "P4":
+		return PBMFromImage(img, magicNumber), nil
+	case "P2", "P5", "P3", "P6":
+		if maxValue < 1 || maxValue > 65535 {
+			return nil, fmt.Errorf("%w: max value %d", ErrUnsupported, maxValue)
+		}
+		if magicNumber == "P2" || magicNumber == "P5" {
+			return PGMFromImage(img, maxValue, magicNumber), nil
+		}
+		return PPMFromImage(img, maxValue, magicNumber), nil
+	}
+	return nil, fmt.Errorf("%w: magic number %q", ErrUnsupported, magicNumber)
+}
+
+// Read lit un fichier Netpbm quel que soit son format.
+func Read(filename string) (Image, error) {
+	return ReadWithOptions(filename, nil)
+}
+
+// ReadWithOptions lit un fichier Netpbm quel que soit son format, avec les
+// options de décodage opts, qui peuvent être nil.
+func ReadWithOptions(filename string, opts *DecodeOptions) (Image, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodeWithOptions(file, opts)
+}
+
+// Decode lit une image Netpbm depuis r. Si r n'est pas déjà un *bufio.Reader,
+// Decode peut lire au-delà de la fin de l'image ; StreamReader permet de lire
+// plusieurs images à la suite. Le numéro magique détermine le type
+// concret renvoyé : *PBM pour P1 et P4, *PGM pour P2 et P5, *PPM pour P3 et P6.
+// Une image P7 est renvoyée comme *PBM, *PGM ou *PPM lorsque son type de tuple
+// est BLACKANDWHITE, GRAYSCALE ou RGB, et comme *PAM sinon.
+func Decode(r io.Reader) (Image, error) {
+	return DecodeWithOptions(r, nil)
+}
+
+// DecodeWithOptions fait comme Decode, avec les options de décodage opts, qui
+// peuvent être nil.
+func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (Image, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil {
+		return nil, &ParseError{Line: 1, X: -1, Y: -1, Err: ErrBadMagic}
+	}
+
+	switch string(magic) {
+	case "P1", "P4":
+		img, err := DecodePBMWithOptions(br, opts)
+		if err != nil {
+			return nil, err
+		}
+		return img, nil
+	case "P2", "P5":
+		img, err := DecodePGMWithOptions(br, opts)
+		if err != nil {
+			return nil, err
+		}
+		return img, nil
+	case "P3", "P6":
+		img, err := DecodePPMWithOptions(br, opts)
+		if err != nil {
+			return nil, err
+		}
+		return img, nil
+	case "P7":
+		img, err := DecodePAMWithOptions(br, opts)
+		if err != nil {
+			return nil, err
+		}
+		return fromPAM(img), nil
+	}
+	return nil, &ParseError{Line: 1, X: -1, Y: -1, Err: ErrBadMagic, Detail: strconv.Quote(string(magic))}
+}
+
+// DecodeConfig lit uniquement l'en-tête d'une image Netpbm depuis r, sans
+// lire ni allouer ses pixels, et renvoie ses dimensions, son modèle de couleur
+// et le nom de son format ("pbm", "pgm", "ppm" ou "pam"). Elle permet de
+// vérifier la taille d'une image avant de la décoder.
+func DecodeConfig(r io.Reader) (image.Config, string, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil {
+		return image.Config{}, "", &ParseError{Line: 1, X: -1, Y: -1, Err: ErrBadMagic}
+	}
+
+	switch string(magic) {
+	case "P1", "P4":
+		config, err := decodePBMConfig(br)
+		return config, "pbm", err
+	case "P2", "P5":
+		config, err := decodePGMConfig(br)
+		return config, "pgm", err
+	case "P3", "P6":
+		config, err := decodePPMConfig(br)
+		return config, "ppm", err
+	case "P7":
+		config, err := decodePAMConfig(br)
+		return config, "pam", err
+	}
+	return image.Config{}, "", &ParseError{Line: 1, X: -1, Y: -1, Err: ErrBadMagic, Detail: strconv.Quote(string(magic))}
+}
+
+// fromPAM convertit une image PAM vers le type PBM, PGM ou PPM correspondant à
+// son type de tuple, lorsqu'il en existe un.
+func fromPAM(img *PAM) Image {
+	switch {
+	case img.TupleType() == "BLACKANDWHITE" && img.Depth() == 1 && img.MaxValue() == 1:
+		return img.ToPBM()
+	case img.TupleType() == "GRAYSCALE" && img.Depth() == 1:
+		return img.ToPGM()
+	case img.TupleType() == "RGB" && img.Depth() == 3:
+		return img.ToPPM()
+	}
+	return img
+}
+
+// EncodePAM écrit img dans w au format PAM (P7), quel que soit son type.
+func EncodePAM(w io.Writer, img Image) error {
+	switch img := img.(type) {
+	case *PAM:
+		return img.Encode(w)
+	case *PBM:
+		return PAMFromPBM(img).Encode(w)
+	case *PGM:
+		return PAMFromPGM(img).Encode(w)
+	case *PPM:
+		return PAMFromPPM(img).Encode(w)
+	}
+	return fmt.Errorf("%w: image type %T", ErrUnsupported, img)
+}
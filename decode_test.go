@@ -0,0 +1,317 @@
+package netpbm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDecodeSniffsFormat(t *testing.T) {
+	tests := []struct {
+		content string
+		format  string
+		samples []uint16
+	}{
+		{"P1\n2 1\n0 1\n", "P1", []uint16{1}},
+		{"P4\n2 1\n\x40", "P4", []uint16{1}},
+		{"P2\n2 1\n7\n0 5\n", "P2", []uint16{5}},
+		{"P5\n2 1\n255\n\x00\x05", "P5", []uint16{5}},
+		{"P3\n2 1\n255\n0 0 0 1 2 3\n", "P3", []uint16{1, 2, 3}},
+		{"P6\n2 1\n255\n\x00\x00\x00\x01\x02\x03", "P6", []uint16{1, 2, 3}},
+	}
+	for _, test := range tests {
+		img, err := Decode(strings.NewReader(test.content))
+		if err != nil {
+			t.Errorf("%s: %v", test.format, err)
+			continue
+		}
+		if img.Format() != test.format {
+			t.Errorf("Wrong format: got %s, want %s", img.Format(), test.format)
+		}
+		if w, h := img.Size(); w != 2 || h != 1 {
+			t.Errorf("%s: wrong size %dx%d", test.format, w, h)
+		}
+		samples := img.Samples(1, 0)
+		if len(samples) != len(test.samples) {
+			t.Errorf("%s: wrong samples %v", test.format, samples)
+			continue
+		}
+		for i := range samples {
+			if samples[i] != test.samples[i] {
+				t.Errorf("%s: wrong samples %v", test.format, samples)
+			}
+		}
+	}
+}
+
+func TestDecodeConcreteTypes(t *testing.T) {
+	img, _ := Decode(strings.NewReader("P1 1 1 0"))
+	if _, ok := img.(*PBM); !ok {
+		t.Errorf("P1 should decode to *PBM, got %T", img)
+	}
+	img, _ = Decode(strings.NewReader("P2 1 1 1 0"))
+	if _, ok := img.(*PGM); !ok {
+		t.Errorf("P2 should decode to *PGM, got %T", img)
+	}
+	img, _ = Decode(strings.NewReader("P3 1 1 1 0 0 0"))
+	if _, ok := img.(*PPM); !ok {
+		t.Errorf("P3 should decode to *PPM, got %T", img)
+	}
+	if _, err := Decode(strings.NewReader("P9 1 1")); err == nil {
+		t.Error("Expected an error for an unknown magic number")
+	}
+}
+
+func TestImageDecode(t *testing.T) {
+	img, format, err := image.Decode(strings.NewReader("P6\n2 1\n255\n\x00\x00\x00\x01\x02\x03"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "ppm" {
+		t.Errorf("Wrong format: %s", format)
+	}
+	if img.At(1, 0) != (color.RGBA{1, 2, 3, 255}) {
+		t.Errorf("Wrong color: %v", img.At(1, 0))
+	}
+
+	config, format, err := image.DecodeConfig(strings.NewReader("P2 # 12 bits\n3 2\n4095\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "pgm" || config.Width != 3 || config.Height != 2 || config.ColorModel != color.Gray16Model {
+		t.Errorf("Wrong config: %s %+v", format, config)
+	}
+
+	img, format, err = image.Decode(strings.NewReader("P1\n2 1\n1 0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "pbm" || img.At(0, 0) != (color.Gray{0}) || img.At(1, 0) != (color.Gray{255}) {
+		t.Errorf("Wrong PBM colors: %s %v %v", format, img.At(0, 0), img.At(1, 0))
+	}
+}
+
+func TestColorScaling(t *testing.T) {
+	img, err := Decode(strings.NewReader("P2\n1 1\n10\n5\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.At(0, 0) != (color.Gray16{Y: 0x7fff}) {
+		t.Errorf("Wrong scaled gray: %v", img.At(0, 0))
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(10, 10, 12, 11))
+	src.Set(10, 10, color.RGBA{255, 128, 0, 255})
+	src.Set(11, 10, color.RGBA{255, 255, 255, 255})
+
+	img, err := FromImage(src, "P3", 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := img.Size(); w != 2 || h != 1 {
+		t.Fatalf("Wrong size: %dx%d", w, h)
+	}
+	samples := img.Samples(0, 0)
+	if samples[0] != 15 || samples[1] != 8 || samples[2] != 0 {
+		t.Errorf("Wrong samples: %v", samples)
+	}
+
+	img, err = FromImage(src, "P5", 255)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := img.(*PGM); !ok || img.Samples(1, 0)[0] != 255 {
+		t.Errorf("Wrong PGM conversion: %T %v", img, img.Samples(1, 0))
+	}
+
+	img, err = FromImage(src, "P4", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Samples(0, 0)[0] != 0 || img.Samples(1, 0)[0] != 0 {
+		t.Errorf("Wrong PBM conversion: %v %v", img.Samples(0, 0), img.Samples(1, 0))
+	}
+
+	if _, err := FromImage(src, "P7", 255); err == nil {
+		t.Error("Expected an error for an unsupported magic number")
+	}
+}
+
+func TestDecodePAM(t *testing.T) {
+	img, err := Decode(strings.NewReader("P7\nWIDTH 1\nHEIGHT 1\nDEPTH 3\nMAXVAL 255\nTUPLTYPE RGB\nENDHDR\n\x01\x02\x03"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := img.(*PPM); !ok {
+		t.Errorf("RGB PAM should decode to *PPM, got %T", img)
+	}
+
+	img, err = Decode(strings.NewReader("P7\nWIDTH 1\nHEIGHT 1\nDEPTH 2\nMAXVAL 255\nTUPLTYPE GRAYSCALE_ALPHA\nENDHDR\n\x01\x02"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := img.(*PAM); !ok {
+		t.Errorf("GRAYSCALE_ALPHA PAM should decode to *PAM, got %T", img)
+	}
+
+	gray, _ := Decode(strings.NewReader("P2 2 1 9 0 9"))
+	var buf bytes.Buffer
+	if err := EncodePAM(&buf, gray); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 9\nTUPLTYPE GRAYSCALE\nENDHDR\n\x00\x09" {
+		t.Errorf("Wrong PAM output: %q", buf.String())
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		content string
+		want    error
+		format  string
+	}{
+		{"P9\n1 1\n", ErrBadMagic, ""},
+		{"", ErrBadMagic, ""},
+		{"P1\n2 x\n", ErrBadHeader, "pbm"},
+		{"P2\n2 1\n70000\n1 2", ErrUnsupported, "pgm"},
+		{"P3\n1 1\n255\n1 2", ErrTruncated, "ppm"},
+		{"P7\nWIDTH 1\nHEIGHT 1\nDEPTH 1\nMAXVAL 1\nENDHDR\n\x02", ErrSampleOutOfRange, "pam"},
+	}
+	for _, test := range tests {
+		_, err := Decode(strings.NewReader(test.content))
+		var perr *ParseError
+		if !errors.Is(err, test.want) || !errors.As(err, &perr) || perr.Format != test.format {
+			t.Errorf("Decode(%q): got %v, want %v", test.content, err, test.want)
+		}
+	}
+}
+
+func TestDecodeModes(t *testing.T) {
+	// En mode Strict, seuls des blancs peuvent suivre la dernière ligne
+	for _, content := range []string{"P5 2 1 255\n\x01\x02\n\n", "P1 2 1\n0 1\n"} {
+		if _, err := DecodeWithOptions(strings.NewReader(content), &DecodeOptions{Mode: Strict}); err != nil {
+			t.Errorf("Strict decode of %q: %v", content, err)
+		}
+	}
+	content := "P5 2 1 255\n\x01\x02garbage"
+	if _, err := Decode(strings.NewReader(content)); err != nil {
+		t.Errorf("Normal decode must ignore trailing data: %v", err)
+	}
+	_, err := DecodeWithOptions(strings.NewReader(content), &DecodeOptions{Mode: Strict})
+	var perr *ParseError
+	if !errors.Is(err, ErrTrailingData) || !errors.As(err, &perr) || perr.Offset != 13 {
+		t.Errorf("Strict decode must reject trailing data, got %v", err)
+	}
+
+	// En mode Lenient, les échantillons sont ramenés dans la plage autorisée et
+	// les données manquantes valent 0
+	tests := []struct {
+		content string
+		want    []uint16
+	}{
+		{"P2 3 1 100\n5 300 -2\n", []uint16{5, 100, 0}},
+		{"P2 3 1 100\n5 x", []uint16{5, 0, 0}},
+		{"P5 2 2 100\n\x10\xc8\x20", []uint16{16, 100, 32, 0}},
+		{"P3 2 1 15\n1 2 3 4 99", []uint16{1, 2, 3, 4, 15, 0}},
+		{"P6 1 2 255\n\x01\x02", []uint16{1, 2, 0, 0, 0, 0}},
+		{"P1 3 1\n1 2", []uint16{1, 0, 0}},
+		{"P4 9 2\n\xff", []uint16{1, 1, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, test := range tests {
+		if _, err := Decode(strings.NewReader(test.content)); err == nil {
+			t.Errorf("Normal decode of %q must fail", test.content)
+		}
+		img, err := DecodeWithOptions(strings.NewReader(test.content), &DecodeOptions{Mode: Lenient})
+		if err != nil {
+			t.Errorf("Lenient decode of %q: %v", test.content, err)
+			continue
+		}
+		var got []uint16
+		width, height := img.Size()
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				got = append(got, img.Samples(x, y)...)
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("Lenient decode of %q: got %v, want %v", test.content, got, test.want)
+		}
+	}
+}
+
+func TestDecodeLimits(t *testing.T) {
+	// L'en-tête annonce une image gigantesque : l'erreur doit arriver avant toute allocation
+	for _, content := range []string{
+		"P5 100000000 100000000 255\n",
+		"P3 100000 100000 255\n",
+		"P4 4000000 1\n",
+		"P7\nWIDTH 1\nHEIGHT 1\nDEPTH 9223372036854775807\nMAXVAL 255\nENDHDR\n",
+	} {
+		_, err := Decode(strings.NewReader(content))
+		if !errors.Is(err, ErrTooLarge) {
+			t.Errorf("Decode(%q): expected ErrTooLarge, got %v", content, err)
+		}
+	}
+
+	content := "P2\n4 2\n255\n1 2 3 4\n5 6 7 8\n"
+	tests := []struct {
+		opts DecodeOptions
+		ok   bool
+	}{
+		{DecodeOptions{MaxWidth: 4, MaxHeight: 2, MaxPixels: 8, MaxBytes: int64(len(content))}, true},
+		{DecodeOptions{MaxWidth: 3}, false},
+		{DecodeOptions{MaxHeight: 1}, false},
+		{DecodeOptions{MaxPixels: 7}, false},
+		{DecodeOptions{MaxBytes: 20}, false},
+		{DecodeOptions{MaxBytes: int64(len(content)) - 1}, false},
+		{DecodeOptions{MaxWidth: -1, MaxHeight: -1, MaxPixels: -1, MaxBytes: -1}, true},
+	}
+	for _, test := range tests {
+		_, err := DecodeWithOptions(strings.NewReader(content), &test.opts)
+		if test.ok && err != nil {
+			t.Errorf("%+v: %v", test.opts, err)
+		}
+		if !test.ok && !errors.Is(err, ErrTooLarge) {
+			t.Errorf("%+v: expected ErrTooLarge, got %v", test.opts, err)
+		}
+	}
+
+	// Un en-tête seul, dans les limites, ne réserve pas la mémoire de l'image
+	// entière : elle n'est allouée qu'au fil des données reçues
+	for _, content := range []string{
+		"P6 16000 16000 255\n",
+		"P5 16000 16000 65535\n",
+		"P4 16000 16000\n",
+		"P7\nWIDTH 16000\nHEIGHT 16000\nDEPTH 4\nMAXVAL 255\nENDHDR\n",
+		"PF\n12000 12000\n-1\n",
+	} {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		var err error
+		if content[1] == 'F' {
+			_, err = DecodePFM(strings.NewReader(content))
+		} else {
+			_, err = Decode(strings.NewReader(content))
+		}
+		runtime.ReadMemStats(&after)
+		if !errors.Is(err, ErrTruncated) {
+			t.Errorf("Decode(%q): expected ErrTruncated, got %v", content, err)
+		}
+		if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 16<<20 {
+			t.Errorf("Decode(%q): %d MB allocated for a header alone", content, allocated>>20)
+		}
+	}
+
+	// DecodeConfig renvoie les dimensions sans lire les pixels, même au-delà des limites
+	config, format, err := DecodeConfig(strings.NewReader("P5 100000000 100000000 255\n"))
+	if err != nil || format != "pgm" || config.Width != 100000000 || config.Height != 100000000 {
+		t.Errorf("Wrong config: %+v, %q, %v", config, format, err)
+	}
+}
@@ -0,0 +1,22 @@
+package netpbm
+
+import "Netbpm/internal/pnm"
+
+// Erreurs sentinelles renvoyées, enveloppées dans une *ParseError, par Decode
+// et par les fonctions de lecture de chaque format (ReadPBM, ReadPGM, ReadPPM,
+// ReadPAM, ReadPFM...). Elles se testent avec errors.Is.
+var (
+	ErrBadMagic         = pnm.ErrBadMagic         // Numéro magique absent ou inconnu
+	ErrBadHeader        = pnm.ErrBadHeader        // Dimension, valeur maximale ou mot-clé invalide
+	ErrTruncated        = pnm.ErrTruncated        // Fin des données avant le dernier pixel
+	ErrBadSample        = pnm.ErrBadSample        // Échantillon ASCII qui n'est pas un entier
+	ErrSampleOutOfRange = pnm.ErrSampleOutOfRange // Échantillon supérieur à la valeur maximale
+	ErrUnsupported      = pnm.ErrUnsupported      // Format ou valeur maximale non pris en charge
+	ErrTrailingData     = pnm.ErrTrailingData     // Données après la dernière ligne, en mode Strict
+	ErrTooLarge         = pnm.ErrTooLarge         // Dimensions ou taille au-delà des limites de DecodeOptions
+)
+
+// ParseError décrit une erreur de lecture : le format lu, la position de
+// l'élément fautif (octet et ligne), le pixel concerné et l'erreur sentinelle.
+// Elle s'obtient avec errors.As.
+type ParseError = pnm.ParseError
@@ -0,0 +1,70 @@
+package netpbm
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+
+	"Netbpm/internal/pnm"
+)
+
+// Header décrit l'en-tête d'une image Netpbm : numéro magique, dimensions,
+// valeur maximale, profondeur, type de tuple (PAM seulement), commentaires et
+// position en octets du premier octet des pixels.
+type Header = pnm.Header
+
+// ReadHeader lit uniquement l'en-tête d'une image Netpbm depuis r, de P1 à P7,
+// sans lire ni allouer ses pixels. Aucune limite de DecodeOptions n'est
+// appliquée : c'est à l'appelant de juger les dimensions renvoyées.
+func ReadHeader(r io.Reader) (*Header, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil {
+		return nil, &ParseError{Line: 1, X: -1, Y: -1, Err: ErrBadMagic}
+	}
+
+	// L'en-tête est lu par la fonction du format, qui s'arrête au dernier jeton
+	hr := pnm.NewReader(br)
+	switch string(magic) {
+	case "P1", "P4":
+		magicNumber, width, height, err := readPBMHeader(hr)
+		if err != nil {
+			return nil, err
+		}
+		return pnm.NewHeader(hr, magicNumber, width, height, 1, 1), nil
+	case "P2", "P5":
+		magicNumber, width, height, maxVal, err := readPGMHeader(hr)
+		if err != nil {
+			return nil, err
+		}
+		return pnm.NewHeader(hr, magicNumber, width, height, maxVal, 1), nil
+	case "P3", "P6":
+		magicNumber, width, height, maxVal, err := readPPMHeader(hr)
+		if err != nil {
+			return nil, err
+		}
+		return pnm.NewHeader(hr, magicNumber, width, height, maxVal, 3), nil
+	case "P7":
+		pam, err := readPAMHeader(hr)
+		if err != nil {
+			return nil, err
+		}
+		header := pnm.NewHeader(hr, "P7", pam.width, pam.height, pam.max, pam.depth)
+		header.TupleType = pam.tupleType
+		return header, nil
+	}
+	return nil, &ParseError{Line: 1, X: -1, Y: -1, Err: ErrBadMagic, Detail: strconv.Quote(string(magic))}
+}
+
+// ReadHeaderFile lit uniquement l'en-tête du fichier Netpbm filename. Seuls
+// les premiers octets du fichier sont lus.
+func ReadHeaderFile(filename string) (*Header, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadHeader(file)
+}
@@ -0,0 +1,46 @@
+package netpbm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadHeader(t *testing.T) {
+	tests := []struct {
+		content string
+		want    Header
+	}{
+		{"P1\n# CREATOR: scanner-07\n3 2\n1 0 1\n0 1 0\n", Header{Format: "P1", Width: 3, Height: 2, MaxValue: 1, Depth: 1, Comments: []string{"CREATOR: scanner-07"}, RasterOffset: 29}},
+		{"P4 16 1\n\xff\xff", Header{Format: "P4", Width: 16, Height: 1, MaxValue: 1, Depth: 1, RasterOffset: 8}},
+		{"P2 #un\n2 1 #deux\n15\n0 15\n", Header{Format: "P2", Width: 2, Height: 1, MaxValue: 15, Depth: 1, Comments: []string{"un", "deux"}, RasterOffset: 20}},
+		{"P5 2 1 65535\n\x00\x01\x02\x03", Header{Format: "P5", Width: 2, Height: 1, MaxValue: 65535, Depth: 1, RasterOffset: 13}},
+		{"P3 1 1 255\n1 2 3\n", Header{Format: "P3", Width: 1, Height: 1, MaxValue: 255, Depth: 3, RasterOffset: 11}},
+		{"P6\n1 1\n255\n\x01\x02\x03", Header{Format: "P6", Width: 1, Height: 1, MaxValue: 255, Depth: 3, RasterOffset: 11}},
+		{"P7\n# exposure=1/60\nWIDTH 2\nHEIGHT 1\nDEPTH 2\nMAXVAL 255\nTUPLTYPE GRAYSCALE_ALPHA\nENDHDR\n\x01\x02\x03\x04", Header{Format: "P7", Width: 2, Height: 1, MaxValue: 255, Depth: 2, TupleType: "GRAYSCALE_ALPHA", Comments: []string{"exposure=1/60"}, RasterOffset: 87}},
+	}
+	for _, test := range tests {
+		header, err := ReadHeader(strings.NewReader(test.content))
+		if err != nil {
+			t.Errorf("%q: %v", test.content, err)
+			continue
+		}
+		if !reflect.DeepEqual(*header, test.want) {
+			t.Errorf("%q: got %+v, want %+v", test.content, *header, test.want)
+		}
+	}
+
+	// Les pixels ne sont pas lus : une image tronquée ou gigantesque passe
+	header, err := ReadHeader(strings.NewReader("P6 100000000 100000000 255\n"))
+	if err != nil || header.Width != 100000000 {
+		t.Errorf("Wrong header: %+v, %v", header, err)
+	}
+
+	if _, err := ReadHeader(strings.NewReader("P9 1 1\n")); !errors.Is(err, ErrBadMagic) {
+		t.Errorf("Expected ErrBadMagic, got %v", err)
+	}
+	if _, err := ReadHeader(strings.NewReader("P2 3\n")); !errors.Is(err, ErrBadHeader) {
+		t.Errorf("Expected ErrBadHeader, got %v", err)
+	}
+}
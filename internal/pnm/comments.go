@@ -0,0 +1,55 @@
+package pnm
+
+import (
+	"io"
+	"strings"
+)
+
+// WriteComments écrit chaque commentaire de comments sur sa propre ligne,
+// précédé de "# ". Un commentaire qui contient des fins de ligne est écrit
+// sur plusieurs lignes.
+func WriteComments(w io.Writer, comments []string) error {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimRight(line, "\r")
+			if _, err := io.WriteString(w, "# "+line+"\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Metadata cherche la valeur associée à key dans comments. Un commentaire de
+// la forme "key=valeur" donne toute la suite du commentaire ; sinon, le
+// premier mot "key=valeur" d'un commentaire comme
+// "CREATOR: scanner-07 exposure=1/60" est retenu.
+func Metadata(comments []string, key string) (string, bool) {
+	prefix := key + "="
+	for _, comment := range comments {
+		if strings.HasPrefix(comment, prefix) {
+			return comment[len(prefix):], true
+		}
+	}
+	for _, comment := range comments {
+		for _, field := range strings.Fields(comment) {
+			if strings.HasPrefix(field, prefix) {
+				return field[len(prefix):], true
+			}
+		}
+	}
+	return "", false
+}
+
+// SetMetadata renvoie comments où le commentaire "key=..." est remplacé par
+// "key=value", ou complété par ce commentaire s'il n'existe pas encore.
+func SetMetadata(comments []string, key, value string) []string {
+	prefix := key + "="
+	for i, comment := range comments {
+		if strings.HasPrefix(comment, prefix) {
+			comments[i] = prefix + value
+			return comments
+		}
+	}
+	return append(comments, prefix+value)
+}
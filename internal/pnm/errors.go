@@ -0,0 +1,105 @@
+package pnm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Erreurs sentinelles communes à tous les formats. Les erreurs de lecture
+// sont des *ParseError qui enveloppent l'une d'elles, et se testent avec
+// errors.Is.
+var (
+	ErrBadMagic         = errors.New("netpbm: bad magic number")
+	ErrBadHeader        = errors.New("netpbm: bad header")
+	ErrTruncated        = errors.New("netpbm: truncated data")
+	ErrBadSample        = errors.New("netpbm: invalid sample")
+	ErrSampleOutOfRange = errors.New("netpbm: sample out of range")
+	ErrUnsupported      = errors.New("netpbm: unsupported format")
+	ErrTrailingData     = errors.New("netpbm: trailing data after image")
+	ErrTooLarge         = errors.New("netpbm: image too large")
+)
+
+// ParseError décrit une erreur de lecture et l'endroit du flux où elle a été
+// détectée.
+type ParseError struct {
+	Format string // Format lu : "pbm", "pgm", "ppm", "pam" ou "pfm", vide s'il est inconnu
+	Offset int64  // Position, en octets depuis le début de l'image, de l'élément fautif
+	Line   int    // Ligne de l'élément fautif, à partir de 1
+	X, Y   int    // Pixel concerné, ou -1 pour une erreur dans l'en-tête
+	Err    error  // Erreur sentinelle : ErrBadMagic, ErrBadHeader, ErrTruncated...
+	Detail string // Précision facultative, par exemple le champ ou la valeur fautive
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	b.WriteString("netpbm: ")
+	if e.Format != "" {
+		b.WriteString(e.Format + ": ")
+	}
+	b.WriteString(strings.TrimPrefix(e.Err.Error(), "netpbm: "))
+	if e.Detail != "" {
+		b.WriteString(": " + e.Detail)
+	}
+	if e.X >= 0 && e.Y >= 0 {
+		fmt.Fprintf(&b, " at pixel (%d, %d)", e.X, e.Y)
+	}
+	fmt.Fprintf(&b, " (line %d, byte %d)", e.Line, e.Offset)
+	return b.String()
+}
+
+// Unwrap renvoie l'erreur sentinelle, pour errors.Is.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// HeaderError renvoie une erreur d'en-tête située au début du dernier élément lu.
+func (hr *Reader) HeaderError(format string, err error, detail string) *ParseError {
+	return hr.PixelError(format, -1, -1, err, detail)
+}
+
+// PixelError renvoie une erreur portant sur le pixel (x, y), située au début
+// du dernier élément lu.
+func (hr *Reader) PixelError(format string, x, y int, err error, detail string) *ParseError {
+	// Une fois la limite d'octets dépassée, toute erreur de lecture en découle
+	if hr.tooLarge != nil {
+		hr.tooLarge.Format, hr.tooLarge.X, hr.tooLarge.Y = format, x, y
+		return hr.tooLarge
+	}
+	return &ParseError{
+		Format: format,
+		Offset: hr.start,
+		Line:   hr.startLine,
+		X:      x,
+		Y:      y,
+		Err:    err,
+		Detail: detail,
+	}
+}
+
+// Sample lit un échantillon ASCII du pixel (x, y) et vérifie qu'il ne dépasse
+// pas maxVal. name précise l'échantillon dans les messages d'erreur
+// ("red value"...), il peut être vide. En mode Lenient, un échantillon
+// illisible vaut 0 et un échantillon hors limites est ramené dans 0..maxVal.
+func (hr *Reader) Sample(format, name string, x, y, maxVal int) (uint16, error) {
+	token, err := hr.Token()
+	if err != nil {
+		return 0, hr.PixelError(format, x, y, ErrTruncated, name)
+	}
+	v, err := strconv.Atoi(token)
+	lenient := hr.opts.Mode == Lenient
+	switch {
+	case err != nil && lenient:
+		return 0, nil
+	case err != nil:
+		return 0, hr.PixelError(format, x, y, ErrBadSample, strings.TrimSpace(name+" "+strconv.Quote(token)))
+	case lenient && v < 0:
+		return 0, nil
+	case lenient && v > maxVal:
+		return uint16(maxVal), nil
+	case v < 0 || v > maxVal:
+		return 0, hr.PixelError(format, x, y, ErrSampleOutOfRange, strings.TrimSpace(fmt.Sprintf("%s %d (max %d)", name, v, maxVal)))
+	}
+	return uint16(v), nil
+}
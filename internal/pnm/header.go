@@ -0,0 +1,27 @@
+package pnm
+
+// Header décrit l'en-tête d'une image Netpbm, lu sans toucher aux pixels.
+type Header struct {
+	Format       string   // Numéro magique, de P1 à P7
+	Width        int      // Largeur de l'image
+	Height       int      // Hauteur de l'image
+	MaxValue     int      // Valeur maximale d'un échantillon, 1 pour PBM
+	Depth        int      // Nombre d'échantillons par pixel : 1 pour PBM et PGM, 3 pour PPM
+	TupleType    string   // Type de tuple d'une image PAM, vide pour les autres formats
+	Comments     []string // Commentaires de l'en-tête, sans le caractère #
+	RasterOffset int64    // Position en octets du premier octet des pixels
+}
+
+// NewHeader crée un Header à partir de la position courante de hr, qui vient
+// de lire le dernier jeton de l'en-tête.
+func NewHeader(hr *Reader, format string, width, height, maxVal, depth int) *Header {
+	return &Header{
+		Format:       format,
+		Width:        width,
+		Height:       height,
+		MaxValue:     maxVal,
+		Depth:        depth,
+		Comments:     hr.Comments(),
+		RasterOffset: hr.Offset(),
+	}
+}
@@ -0,0 +1,183 @@
+package pnm
+
+import (
+	"fmt"
+	"math"
+)
+
+// Mode choisit la réaction du décodeur face à un fichier mal formé.
+type Mode int
+
+const (
+	// Normal rejette les échantillons hors limites et les données manquantes,
+	// mais ignore ce qui suit la dernière ligne de l'image.
+	Normal Mode = iota
+	// Strict rejette en plus tout ce qui n'est pas un blanc après la
+	// dernière ligne de l'image.
+	Strict
+	// Lenient ramène les échantillons hors limites à la valeur maximale,
+	// remplace les échantillons illisibles par 0 et complète par des 0 les
+	// données manquantes.
+	Lenient
+)
+
+// Limites appliquées par défaut, lorsque le champ correspondant de
+// DecodeOptions vaut 0.
+const (
+	DefaultMaxWidth  = 1 << 20
+	DefaultMaxHeight = 1 << 20
+	DefaultMaxPixels = 1 << 28
+	DefaultMaxBytes  = 1 << 31
+)
+
+// DecodeOptions règle le comportement des fonctions de décodage. Un pointeur
+// nil équivaut à la valeur zéro, c'est-à-dire au mode Normal avec les limites
+// par défaut.
+//
+// Les limites sont vérifiées dès la lecture de l'en-tête, avant toute
+// allocation, et leur dépassement renvoie une erreur ErrTooLarge. Une limite
+// nulle est remplacée par sa valeur par défaut, une limite négative est
+// désactivée. Les lecteurs ligne par ligne, qui n'allouent qu'une ligne,
+// n'appliquent par défaut que MaxWidth : les autres limites ne s'y appliquent
+// que si elles sont fixées.
+type DecodeOptions struct {
+	Mode      Mode
+	MaxWidth  int   // Largeur maximale, en pixels
+	MaxHeight int   // Hauteur maximale, en pixels
+	MaxPixels int64 // Nombre maximal de pixels, largeur × hauteur
+	MaxBytes  int64 // Nombre maximal d'octets lus, en-tête compris
+}
+
+// limit renvoie la limite v, ou def si v est nulle ; le résultat est négatif
+// si la limite est désactivée.
+func limit(v, def int64) int64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// limit renvoie la limite v du Reader, ou def si v est nulle. En lecture
+// ligne par ligne, une limite nulle est désactivée.
+func (hr *Reader) limit(v, def int64) int64 {
+	if v == 0 && hr.rows {
+		return -1
+	}
+	return limit(v, def)
+}
+
+// Mode renvoie le mode de décodage du Reader.
+func (hr *Reader) Mode() Mode {
+	return hr.opts.Mode
+}
+
+// CheckSize vérifie les dimensions lues dans l'en-tête par rapport aux limites
+// du Reader. Chaque pixel compte samples échantillons occupant au moins
+// bitsPerSample bits dans le fichier (1 pour P4, 8 pour les formats ASCII,
+// 8 ou 16 pour les formats binaires...), ce qui permet de rejeter d'emblée une
+// image dont les données dépasseraient MaxBytes.
+func (hr *Reader) CheckSize(format string, width, height, samples, bitsPerSample int) error {
+	if max := limit(int64(hr.opts.MaxWidth), DefaultMaxWidth); max >= 0 && int64(width) > max {
+		return hr.HeaderError(format, ErrTooLarge, fmt.Sprintf("width %d (max %d)", width, max))
+	}
+	if max := hr.limit(int64(hr.opts.MaxHeight), DefaultMaxHeight); max >= 0 && int64(height) > max {
+		return hr.HeaderError(format, ErrTooLarge, fmt.Sprintf("height %d (max %d)", height, max))
+	}
+	pixels, ok := mul(int64(width), int64(height))
+	if max := hr.limit(hr.opts.MaxPixels, DefaultMaxPixels); max >= 0 && (!ok || pixels > max) {
+		return hr.HeaderError(format, ErrTooLarge, fmt.Sprintf("%d×%d pixels (max %d)", width, height, max))
+	}
+	rowSamples, ok1 := mul(int64(width), int64(samples))
+	rowBits, ok2 := mul(rowSamples, int64(bitsPerSample))
+	rasterBytes, ok3 := mul((rowBits+7)/8, int64(height))
+	if max := hr.maxBytes(); max >= 0 && (!ok1 || !ok2 || !ok3 || rasterBytes > max-hr.offset) {
+		return hr.HeaderError(format, ErrTooLarge, fmt.Sprintf("image data exceeds %d bytes", max))
+	}
+	return nil
+}
+
+// maxBytes renvoie le nombre maximal d'octets à lire, négatif si illimité.
+func (hr *Reader) maxBytes() int64 {
+	return hr.limit(hr.opts.MaxBytes, DefaultMaxBytes)
+}
+
+// CheckRowSize fait comme CheckSize pour un lecteur ligne par ligne : seule
+// la largeur, qui fixe la taille de la ligne allouée, est limitée par défaut.
+// MaxHeight, MaxPixels et MaxBytes ne s'appliquent que s'ils sont fixés, y
+// compris pour les octets lus ensuite.
+func (hr *Reader) CheckRowSize(format string, width, height, samples, bitsPerSample int) error {
+	hr.rows = true
+	return hr.CheckSize(format, width, height, samples, bitsPerSample)
+}
+
+// mul renvoie a × b pour a, b ≥ 0, et false en cas de dépassement de capacité.
+func mul(a, b int64) (int64, bool) {
+	if a != 0 && b > math.MaxInt64/a {
+		return 0, false
+	}
+	return a * b, true
+}
+
+// ReadRaster lit len(p) octets de données binaires pour la ligne y. Si les
+// données s'arrêtent avant, le mode Lenient complète p par des 0 et renvoie
+// truncated = true, les autres modes renvoient une erreur ErrTruncated.
+func (hr *Reader) ReadRaster(format string, p []byte, y int) (truncated bool, err error) {
+	n, err := hr.ReadFull(p)
+	if err == nil {
+		return false, nil
+	}
+	if hr.tooLarge != nil {
+		return false, hr.tooLarge
+	}
+	if hr.opts.Mode != Lenient {
+		return false, hr.PixelError(format, 0, y, ErrTruncated, "")
+	}
+	clear(p[n:])
+	return true, nil
+}
+
+// CheckSamples vérifie les échantillons binaires de la ligne y, depth
+// échantillons par pixel, qui viennent d'être lus par ReadRaster. Le mode
+// Lenient ramène les échantillons trop grands à maxVal.
+func (hr *Reader) CheckSamples(format string, samples []uint16, y, depth, maxVal int) error {
+	for k, v := range samples {
+		if int(v) <= maxVal {
+			continue
+		}
+		if hr.opts.Mode == Lenient {
+			samples[k] = uint16(maxVal)
+			continue
+		}
+		e := hr.PixelError(format, k/depth, y, ErrSampleOutOfRange, fmt.Sprintf("%d (max %d)", v, maxVal))
+		e.Offset += int64(k * SampleSize(maxVal))
+		return e
+	}
+	return nil
+}
+
+// Recover indique si err, renvoyée pendant la lecture des données, signale
+// des données manquantes que le mode Lenient remplace par des 0.
+func (hr *Reader) Recover(err error) bool {
+	e, ok := err.(*ParseError)
+	return ok && e.Err == ErrTruncated && hr.opts.Mode == Lenient
+}
+
+// CheckTrailing vérifie, en mode Strict, qu'il ne reste que des blancs après
+// la dernière ligne de l'image. Elle ne lit rien dans les autres modes.
+func (hr *Reader) CheckTrailing(format string) error {
+	if hr.opts.Mode != Strict {
+		return nil
+	}
+	for {
+		b, err := hr.ReadByte()
+		if hr.tooLarge != nil {
+			return hr.tooLarge
+		}
+		if err != nil {
+			return nil
+		}
+		if !IsSpace(b) {
+			return hr.HeaderError(format, ErrTrailingData, "")
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Package pnm regroupe le code commun aux lecteurs des formats Netpbm.
+package pnm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Reader découpe l'en-tête d'un fichier Netpbm en jetons séparés par des blancs.
+// Les commentaires (du caractère # jusqu'à la fin de la ligne) sont sautés
+// où qu'ils se trouvent dans l'en-tête, et leur texte est conservé. Reader
+// compte les octets et les lignes lus, pour situer les erreurs.
+type Reader struct {
+	r         *bufio.Reader
+	opts      DecodeOptions
+	offset    int64       // Nombre d'octets lus
+	line      int         // Ligne courante, à partir de 1
+	start     int64       // Position du début du dernier élément lu
+	startLine int         // Ligne du début du dernier élément lu
+	tooLarge  *ParseError // Erreur ErrTooLarge, une fois MaxBytes atteint
+	rows      bool        // Lecture ligne par ligne, voir CheckRowSize
+	comments  []string    // Texte des commentaires sautés, dans l'ordre de lecture
+}
+
+// NewReader crée un Reader lisant depuis r, en mode Normal.
+func NewReader(r io.Reader) *Reader {
+	return NewReaderOptions(r, nil)
+}
+
+// NewReaderOptions crée un Reader lisant depuis r avec les options opts,
+// qui peuvent être nil.
+func NewReaderOptions(r io.Reader, opts *DecodeOptions) *Reader {
+	hr := &Reader{r: bufio.NewReader(r), line: 1, startLine: 1}
+	if opts != nil {
+		hr.opts = *opts
+	}
+	return hr
+}
+
+// Buffered renvoie le lecteur sous-jacent. Après la lecture du dernier jeton de
+// l'en-tête, il est positionné sur le premier octet des données de l'image :
+// Token consomme exactement un blanc après chaque jeton. Les octets lus
+// directement sur ce lecteur ne sont pas comptés.
+func (hr *Reader) Buffered() *bufio.Reader {
+	return hr.r
+}
+
+// Offset renvoie le nombre d'octets lus depuis la création du Reader.
+func (hr *Reader) Offset() int64 {
+	return hr.offset
+}
+
+// Line renvoie le numéro de la ligne courante, à partir de 1.
+func (hr *Reader) Line() int {
+	return hr.line
+}
+
+// Comments renvoie le texte des commentaires sautés jusqu'ici, sans le
+// caractère # ni les blancs qui l'entourent.
+func (hr *Reader) Comments() []string {
+	return hr.comments
+}
+
+// readByte lit un octet en tenant à jour la position courante.
+func (hr *Reader) readByte() (byte, error) {
+	if hr.tooLarge != nil {
+		return 0, hr.tooLarge
+	}
+	b, err := hr.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	// Seul un octet effectivement présent au-delà de la limite est une erreur
+	if err := hr.checkBytes(1); err != nil {
+		return 0, err
+	}
+	hr.offset++
+	if b == '\n' {
+		hr.line++
+	}
+	return b, nil
+}
+
+// checkBytes vérifie que n octets de plus peuvent être lus sans dépasser
+// MaxBytes.
+func (hr *Reader) checkBytes(n int64) error {
+	if hr.tooLarge != nil {
+		return hr.tooLarge
+	}
+	if max := hr.maxBytes(); max >= 0 && hr.offset+n > max {
+		hr.tooLarge = &ParseError{
+			Offset: hr.offset,
+			Line:   hr.line,
+			X:      -1,
+			Y:      -1,
+			Err:    ErrTooLarge,
+			Detail: fmt.Sprintf("more than %d bytes", max),
+		}
+		return hr.tooLarge
+	}
+	return nil
+}
+
+// mark retient la position courante comme début de l'élément en cours de lecture.
+func (hr *Reader) mark() {
+	hr.start, hr.startLine = hr.offset, hr.line
+}
+
+// ReadByte lit un seul octet des données de l'image.
+func (hr *Reader) ReadByte() (byte, error) {
+	hr.mark()
+	return hr.readByte()
+}
+
+// ReadFull lit exactement len(p) octets des données binaires de l'image.
+// Les lignes n'y sont pas comptées.
+func (hr *Reader) ReadFull(p []byte) (int, error) {
+	hr.mark()
+	if err := hr.checkBytes(int64(len(p))); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(hr.r, p)
+	hr.offset += int64(n)
+	return n, err
+}
+
+// Token renvoie le prochain jeton de l'en-tête. io.EOF est renvoyé s'il ne
+// reste plus aucun jeton.
+func (hr *Reader) Token() (string, error) {
+	// Saut des blancs et des commentaires précédant le jeton
+	var c byte
+	for {
+		hr.mark()
+		b, err := hr.readByte()
+		if err != nil {
+			return "", err
+		}
+		if b == '#' {
+			if err := hr.skipComment(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if !IsSpace(b) {
+			c = b
+			break
+		}
+	}
+
+	// Lecture du jeton jusqu'au premier blanc, qui est consommé
+	token := []byte{c}
+	for {
+		b, err := hr.readByte()
+		if err == io.EOF {
+			return string(token), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if b == '#' {
+			// La fin de ligne du commentaire tient lieu de séparateur
+			if err := hr.skipComment(); err != nil && err != io.EOF {
+				return "", err
+			}
+			return string(token), nil
+		}
+		if IsSpace(b) {
+			return string(token), nil
+		}
+		token = append(token, b)
+	}
+}
+
+// Int lit le prochain jeton et le convertit en entier.
+func (hr *Reader) Int() (int, error) {
+	token, err := hr.Token()
+	if err == io.EOF {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, errors.New("pnm: invalid integer " + strconv.Quote(token))
+	}
+	return n, nil
+}
+
+// skipComment avance jusqu'à la fin de la ligne courante, fin de ligne comprise,
+// et conserve le texte du commentaire.
+func (hr *Reader) skipComment() error {
+	var text []byte
+	for {
+		b, err := hr.readByte()
+		if err != nil {
+			if err == io.EOF {
+				hr.comments = append(hr.comments, strings.TrimSpace(string(text)))
+			}
+			return err
+		}
+		if b == '\n' || b == '\r' {
+			hr.comments = append(hr.comments, strings.TrimSpace(string(text)))
+			return nil
+		}
+		text = append(text, b)
+	}
+}
+
+// IsSpace indique si b est un blanc au sens de la spécification Netpbm.
+func IsSpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package pnm
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTokenSkipsComments(t *testing.T) {
+	hr := NewReader(strings.NewReader("P2 # CREATOR: GIMP\n# encore\n15\t15 #taille\n  255\nraster"))
+	want := []string{"P2", "15", "15", "255"}
+	for _, w := range want {
+		token, err := hr.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != w {
+			t.Errorf("Wrong token: got %q, want %q", token, w)
+		}
+	}
+	rest, _ := io.ReadAll(hr.Buffered())
+	if string(rest) != "raster" {
+		t.Errorf("Wrong raster start: %q", rest)
+	}
+	comments := hr.Comments()
+	if len(comments) != 3 || comments[0] != "CREATOR: GIMP" || comments[1] != "encore" || comments[2] != "taille" {
+		t.Errorf("Wrong comments: %q", comments)
+	}
+}
+
+func TestTokenSingleWhitespace(t *testing.T) {
+	// Un seul blanc sépare l'en-tête des données binaires, même si celles-ci
+	// commencent par un octet qui ressemble à un blanc.
+	hr := NewReader(strings.NewReader("P5 2 1 255\n\n\x01"))
+	for i := 0; i < 4; i++ {
+		if _, err := hr.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rest, _ := io.ReadAll(hr.Buffered())
+	if string(rest) != "\n\x01" {
+		t.Errorf("Wrong raster start: %q", rest)
+	}
+}
+
+func TestTokenCommentAfterToken(t *testing.T) {
+	hr := NewReader(strings.NewReader("P1#commentaire\n3 1"))
+	token, err := hr.Token()
+	if err != nil || token != "P1" {
+		t.Fatalf("Wrong magic number: %q, %v", token, err)
+	}
+	w, err := hr.Int()
+	if err != nil || w != 3 {
+		t.Errorf("Wrong width: %d, %v", w, err)
+	}
+	h, err := hr.Int()
+	if err != nil || h != 1 {
+		t.Errorf("Wrong height: %d, %v", h, err)
+	}
+	if _, err := hr.Int(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestSamplesRoundTrip(t *testing.T) {
+	samples := []uint16{0, 1, 0x0fff, 0xabcd, 65535}
+	buf := make([]byte, len(samples)*SampleSize(65535))
+	EncodeSamples(buf, samples, 65535)
+	if string(buf[6:8]) != "\xab\xcd" {
+		t.Errorf("Samples must be big endian: %x", buf)
+	}
+	got := make([]uint16, len(samples))
+	DecodeSamples(got, buf, 65535)
+	for i := range samples {
+		if got[i] != samples[i] {
+			t.Errorf("Wrong sample %d: got %d, want %d", i, got[i], samples[i])
+		}
+	}
+}
+
+func TestReaderPosition(t *testing.T) {
+	hr := NewReader(strings.NewReader("P2\n# commentaire\n3 1\n255\n1 x 3\n"))
+	for i := 0; i < 4; i++ {
+		if _, err := hr.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hr.Offset() != 25 || hr.Line() != 5 {
+		t.Errorf("Wrong position after header: byte %d, line %d", hr.Offset(), hr.Line())
+	}
+	if _, err := hr.Sample("pgm", "", 0, 0, 255); err != nil {
+		t.Fatal(err)
+	}
+	_, err := hr.Sample("pgm", "", 1, 0, 255)
+	perr, ok := err.(*ParseError)
+	if !ok || perr.Err != ErrBadSample || perr.Offset != 27 || perr.Line != 5 || perr.X != 1 {
+		t.Errorf("Wrong error: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package pnm
+
+import "fmt"
+
+// SampleSize renvoie le nombre d'octets occupés par un échantillon binaire :
+// un octet si maxVal est inférieur à 256, deux octets (poids fort en premier) sinon.
+func SampleSize(maxVal int) int {
+	if maxVal < 256 {
+		return 1
+	}
+	return 2
+}
+
+// DecodeSamples convertit les octets bruts de src en échantillons dans dst.
+// src doit contenir len(dst)*SampleSize(maxVal) octets.
+func DecodeSamples(dst []uint16, src []byte, maxVal int) {
+	if SampleSize(maxVal) == 1 {
+		for i := range dst {
+			dst[i] = uint16(src[i])
+		}
+		return
+	}
+	for i := range dst {
+		dst[i] = uint16(src[2*i])<<8 | uint16(src[2*i+1])
+	}
+}
+
+// EncodeSamples convertit les échantillons de src en octets bruts dans dst.
+// dst doit pouvoir contenir len(src)*SampleSize(maxVal) octets.
+func EncodeSamples(dst []byte, src []uint16, maxVal int) {
+	if SampleSize(maxVal) == 1 {
+		for i, v := range src {
+			dst[i] = uint8(v)
+		}
+		return
+	}
+	for i, v := range src {
+		dst[2*i] = uint8(v >> 8)
+		dst[2*i+1] = uint8(v)
+	}
+}
+
+// CheckRange vérifie, avant leur écriture, les échantillons de la ligne y,
+// depth échantillons par pixel : un échantillon supérieur à maxVal serait
+// tronqué en binaire et rendrait le fichier invalide en ASCII.
+func CheckRange(format string, samples []uint16, y, depth, maxVal int) error {
+	for k, v := range samples {
+		if int(v) > maxVal {
+			return fmt.Errorf("%w: %s: row %d, pixel %d: %d (max %d)", ErrSampleOutOfRange, format, y, k/depth, v, maxVal)
+		}
+	}
+	return nil
+}
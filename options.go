@@ -0,0 +1,45 @@
+package netpbm
+
+import "Netbpm/internal/pnm"
+
+// DecodeOptions règle le comportement de DecodeWithOptions et des fonctions
+// DecodeXXXWithOptions de chaque format : le mode de décodage et les limites
+// MaxWidth, MaxHeight, MaxPixels et MaxBytes, vérifiées avant toute allocation.
+// Un pointeur nil équivaut à la valeur zéro, c'est-à-dire au mode Normal avec
+// les limites par défaut ; une limite négative est désactivée. Les lecteurs
+// ligne par ligne n'appliquent par défaut que MaxWidth, les autres limites
+// seulement si elles sont fixées.
+type DecodeOptions = pnm.DecodeOptions
+
+// Limites appliquées lorsque le champ correspondant de DecodeOptions vaut 0.
+const (
+	DefaultMaxWidth  = pnm.DefaultMaxWidth
+	DefaultMaxHeight = pnm.DefaultMaxHeight
+	DefaultMaxPixels = pnm.DefaultMaxPixels
+	DefaultMaxBytes  = pnm.DefaultMaxBytes
+)
+
+// Mode choisit la réaction du décodeur face à un fichier mal formé.
+type Mode = pnm.Mode
+
+const (
+	// Normal rejette les échantillons hors limites et les données manquantes,
+	// mais ignore ce qui suit la dernière ligne de l'image. C'est le mode de
+	// Decode et Read.
+	Normal = pnm.Normal
+	// Strict rejette en plus, avec ErrTrailingData, tout ce qui n'est pas un
+	// blanc après la dernière ligne de l'image.
+	Strict = pnm.Strict
+	// Lenient ramène les échantillons hors limites dans la plage autorisée,
+	// remplace les échantillons illisibles par 0 et complète par des 0 les
+	// données manquantes, pour récupérer ce qui peut l'être d'un fichier abîmé.
+	Lenient = pnm.Lenient
+)
+
+// rasterCap renvoie la capacité initiale d'un tableau qui recevra, au fil de
+// la lecture, les n éléments annoncés par un en-tête. Les décodeurs agrandissent
+// ensuite ce tableau à mesure que les données arrivent : un en-tête de quelques
+// octets ne suffit pas à réserver toute la mémoire d'une image géante.
+func rasterCap(n int) int {
+	return min(n, 1<<16)
+}
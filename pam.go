@@ -0,0 +1,389 @@
+package netpbm
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"Netbpm/internal/pnm"
+)
+
+// Définition de la structure PAM pour représenter une image PAM (P7)
+type PAM struct {
+	data          [][]uint16 // Échantillons de chaque ligne, depth échantillons par pixel
+	width, height int        // Largeur et hauteur de l'image
+	depth         int        // Nombre d'échantillons par pixel
+	max           int        // Valeur maximale autorisée pour un échantillon
+	tupleType     string     // Type de tuple (RGB, GRAYSCALE_ALPHA...), éventuellement vide
+}
+
+// Fonction pour créer une image PAM dont tous les échantillons valent 0 ; comme
+// pour NewPGM, maxValue est ramenée dans la plage 1..65535, et depth vaut au moins 1
+func NewPAM(width, height, depth, maxValue int, tupleType string) *PAM {
+	depth = max(depth, 1)
+	maxValue = clampMaxValue(maxValue)
+	data := make([][]uint16, height)
+	for i := range data {
+		data[i] = make([]uint16, width*depth)
+	}
+	return &PAM{
+		data:      data,
+		width:     width,
+		height:    height,
+		depth:     depth,
+		max:       maxValue,
+		tupleType: tupleType,
+	}
+}
+
+// Fonction pour lire un fichier PAM et créer une instance PAM
+func ReadPAM(filename string) (*PAM, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodePAM(file)
+}
+
+// Fonction pour lire une image PAM depuis r et créer une instance PAM
+func DecodePAM(r io.Reader) (*PAM, error) {
+	return DecodePAMWithOptions(r, nil)
+}
+
+// Fonction pour lire une image PAM depuis r avec les options de décodage opts, qui peuvent être nil
+func DecodePAMWithOptions(r io.Reader, opts *DecodeOptions) (*PAM, error) {
+	hr := pnm.NewReaderOptions(r, opts)
+	pam, err := readPAMHeader(hr)
+	if err != nil {
+		return nil, err
+	}
+	if err := hr.CheckSize("pam", pam.width, pam.height, pam.depth, 8*pnm.SampleSize(pam.max)); err != nil {
+		return nil, err
+	}
+
+	// Les échantillons se suivent sans séparateur, sur un ou deux octets ; en
+	// mode Lenient, les lignes qui suivent la fin des données valent 0. Chaque
+	// ligne n'est allouée qu'une fois lue, voir rasterCap
+	row := make([]byte, pam.width*pam.depth*pnm.SampleSize(pam.max))
+	pam.data = make([][]uint16, 0, rasterCap(pam.height))
+	truncated := false
+	for i := 0; i < pam.height; i++ {
+		samples := make([]uint16, pam.width*pam.depth)
+		pam.data = append(pam.data, samples)
+		if truncated {
+			continue
+		}
+		if truncated, err = hr.ReadRaster("pam", row, i); err != nil {
+			return nil, err
+		}
+		pnm.DecodeSamples(samples, row, pam.max)
+		if err := hr.CheckSamples("pam", samples, i, pam.depth, pam.max); err != nil {
+			return nil, err
+		}
+	}
+	if err := hr.CheckTrailing("pam"); err != nil {
+		return nil, err
+	}
+	return pam, nil
+}
+
+// Fonction pour lire l'en-tête PAM, de P7 jusqu'à ENDHDR
+func readPAMHeader(hr *pnm.Reader) (*PAM, error) {
+	magicNumber, err := hr.Token()
+	if err != nil || magicNumber != "P7" {
+		return nil, hr.HeaderError("pam", pnm.ErrBadMagic, strconv.Quote(magicNumber))
+	}
+
+	pam := &PAM{width: -1, height: -1, depth: -1, max: -1}
+	for {
+		keyword, err := hr.Token()
+		if err != nil {
+			return nil, hr.HeaderError("pam", pnm.ErrBadHeader, "missing ENDHDR")
+		}
+		if keyword == "ENDHDR" {
+			break
+		}
+
+		if keyword == "TUPLTYPE" {
+			value, err := hr.Token()
+			if err != nil {
+				return nil, hr.HeaderError("pam", pnm.ErrBadHeader, "invalid TUPLTYPE")
+			}
+			if pam.tupleType != "" {
+				pam.tupleType += " "
+			}
+			pam.tupleType += value
+			continue
+		}
+
+		value, err := hr.Int()
+		if err != nil {
+			return nil, hr.HeaderError("pam", pnm.ErrBadHeader, "invalid "+keyword)
+		}
+		switch keyword {
+		case "WIDTH":
+			pam.width = value
+		case "HEIGHT":
+			pam.height = value
+		case "DEPTH":
+			pam.depth = value
+		case "MAXVAL":
+			pam.max = value
+		default:
+			return nil, hr.HeaderError("pam", pnm.ErrBadHeader, "unknown keyword "+strconv.Quote(keyword))
+		}
+	}
+
+	if pam.width < 1 || pam.height < 1 || pam.depth < 1 {
+		return nil, hr.HeaderError("pam", pnm.ErrBadHeader, "invalid dimensions")
+	}
+	if pam.max < 1 {
+		return nil, hr.HeaderError("pam", pnm.ErrBadHeader, "invalid MAXVAL")
+	}
+	if pam.max > 65535 {
+		return nil, hr.HeaderError("pam", pnm.ErrUnsupported, fmt.Sprintf("MAXVAL %d", pam.max))
+	}
+	return pam, nil
+}
+
+// Fonction pour lire uniquement l'en-tête d'une image PAM depuis r
+func decodePAMConfig(r io.Reader) (image.Config, error) {
+	pam, err := readPAMHeader(pnm.NewReader(r))
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: pam.ColorModel(), Width: pam.width, Height: pam.height}, nil
+}
+
+// Enregistrement du format auprès du paquet image, pour image.Decode et image.DecodeConfig
+func init() {
+	decode := func(r io.Reader) (image.Image, error) {
+		pam, err := DecodePAM(r)
+		if err != nil {
+			return nil, err
+		}
+		return pam, nil
+	}
+	image.RegisterFormat("pam", "P7", decode, decodePAMConfig)
+}
+
+// Méthode pour obtenir la taille de l'image PAM
+func (pam *PAM) Size() (int, int) {
+	return pam.width, pam.height
+}
+
+// Méthode pour obtenir le numéro magique, toujours P7
+func (pam *PAM) Format() string {
+	return "P7"
+}
+
+// Méthode pour obtenir la valeur maximale autorisée pour un échantillon
+func (pam *PAM) MaxValue() int {
+	return pam.max
+}
+
+// Méthode pour obtenir le nombre d'échantillons par pixel
+func (pam *PAM) Depth() int {
+	return pam.depth
+}
+
+// Méthode pour obtenir le type de tuple de l'image PAM
+func (pam *PAM) TupleType() string {
+	return pam.tupleType
+}
+
+// Méthode pour définir le type de tuple de l'image PAM
+func (pam *PAM) SetTupleType(tupleType string) {
+	pam.tupleType = tupleType
+}
+
+// Méthode pour obtenir les échantillons d'un pixel, tous nuls hors de l'image
+func (pam *PAM) Samples(x, y int) []uint16 {
+	samples := make([]uint16, pam.depth)
+	if !(image.Point{x, y}.In(pam.Bounds())) {
+		return samples
+	}
+	copy(samples, pam.data[y][x*pam.depth:])
+	return samples
+}
+
+// Méthode pour définir les échantillons d'un pixel ; hors de l'image, rien n'est modifié
+func (pam *PAM) SetSamples(x, y int, samples []uint16) {
+	if !(image.Point{x, y}.In(pam.Bounds())) {
+		return
+	}
+	copy(pam.data[y][x*pam.depth:(x+1)*pam.depth], samples)
+}
+
+// Méthode pour savoir si le dernier échantillon de chaque pixel est une opacité
+func (pam *PAM) hasAlpha() bool {
+	if strings.HasSuffix(pam.tupleType, "_ALPHA") {
+		return true
+	}
+	// Sans type de tuple connu, 2 ou 4 échantillons signifient gris ou RVB avec opacité
+	switch pam.tupleType {
+	case "BLACKANDWHITE", "GRAYSCALE", "RGB":
+		return false
+	}
+	return pam.depth == 2 || pam.depth >= 4
+}
+
+// Méthode pour obtenir le modèle de couleur de l'image PAM
+func (pam *PAM) ColorModel() color.Model {
+	return color.NRGBA64Model
+}
+
+// Méthode pour obtenir le rectangle occupé par l'image PAM
+func (pam *PAM) Bounds() image.Rectangle {
+	return image.Rect(0, 0, pam.width, pam.height)
+}
+
+// Méthode pour obtenir la couleur d'un pixel : les profondeurs 1 et 2 sont
+// lues comme du gris, les profondeurs 3 et plus comme du RVB
+func (pam *PAM) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(pam.Bounds())) {
+		return color.NRGBA64{}
+	}
+	scale := func(v uint16) uint16 {
+		return uint16(uint32(v) * 0xffff / uint32(pam.max))
+	}
+	samples := pam.data[y][x*pam.depth : (x+1)*pam.depth]
+
+	c := color.NRGBA64{A: 0xffff}
+	if pam.depth >= 3 {
+		c.R, c.G, c.B = scale(samples[0]), scale(samples[1]), scale(samples[2])
+	} else {
+		c.R = scale(samples[0])
+		c.G, c.B = c.R, c.R
+	}
+	if pam.hasAlpha() {
+		c.A = scale(samples[pam.depth-1])
+	}
+	return c
+}
+
+// Méthode pour sauvegarder l'image PAM dans un fichier
+func (pam *PAM) Save(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := pam.Encode(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Méthode pour écrire l'image PAM dans w ; un échantillon supérieur à la
+// valeur maximale donne ErrSampleOutOfRange
+func (pam *PAM) Encode(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "P7\nWIDTH %d\nHEIGHT %d\nDEPTH %d\nMAXVAL %d\n", pam.width, pam.height, pam.depth, pam.max)
+	if pam.tupleType != "" {
+		fmt.Fprintf(writer, "TUPLTYPE %s\n", pam.tupleType)
+	}
+	fmt.Fprint(writer, "ENDHDR\n")
+
+	row := make([]byte, pam.width*pam.depth*pnm.SampleSize(pam.max))
+	for i := 0; i < pam.height; i++ {
+		if err := pnm.CheckRange("pam", pam.data[i], i, pam.depth, pam.max); err != nil {
+			return err
+		}
+		pnm.EncodeSamples(row, pam.data[i], pam.max)
+		writer.Write(row)
+	}
+	return writer.Flush()
+}
+
+// Méthode pour convertir l'image PAM en image PBM : un pixel est noir si son
+// premier échantillon est dans la moitié basse de la plage (0 pour BLACKANDWHITE)
+func (pam *PAM) ToPBM() *PBM {
+	img := NewPBM(pam.width, pam.height, "P4")
+	for y := 0; y < pam.height; y++ {
+		for x := 0; x < pam.width; x++ {
+			img.Set(x, y, 2*int(pam.data[y][x*pam.depth]) < pam.max)
+		}
+	}
+	return img
+}
+
+// Méthode pour convertir l'image PAM en image PGM, en moyennant les trois
+// premiers échantillons lorsque la profondeur le permet
+func (pam *PAM) ToPGM() *PGM {
+	img := NewPGM(pam.width, pam.height, pam.max, "P5")
+	for y := 0; y < pam.height; y++ {
+		for x := 0; x < pam.width; x++ {
+			samples := pam.data[y][x*pam.depth : (x+1)*pam.depth]
+			if pam.depth >= 3 {
+				img.Set(x, y, uint16((uint32(samples[0])+uint32(samples[1])+uint32(samples[2]))/3))
+			} else {
+				img.Set(x, y, samples[0])
+			}
+		}
+	}
+	return img
+}
+
+// Méthode pour convertir l'image PAM en image PPM, le gris étant recopié sur
+// les trois composantes
+func (pam *PAM) ToPPM() *PPM {
+	img := NewPPM(pam.width, pam.height, pam.max, "P6")
+	for y := 0; y < pam.height; y++ {
+		for x := 0; x < pam.width; x++ {
+			samples := pam.data[y][x*pam.depth : (x+1)*pam.depth]
+			if pam.depth >= 3 {
+				img.Set(x, y, Pixel{R: samples[0], G: samples[1], B: samples[2]})
+			} else {
+				img.Set(x, y, Pixel{R: samples[0], G: samples[0], B: samples[0]})
+			}
+		}
+	}
+	return img
+}
+
+// Fonction pour convertir une image PBM en image PAM BLACKANDWHITE, où 1 représente le blanc ;
+// l'image PAM commence en (0, 0), même si img est une partie d'une image plus grande
+func PAMFromPBM(img *PBM) *PAM {
+	width, height := img.Size()
+	min := img.Bounds().Min
+	pam := NewPAM(width, height, 1, 1, "BLACKANDWHITE")
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !img.BitAt(min.X+x, min.Y+y) {
+				pam.data[y][x] = 1
+			}
+		}
+	}
+	return pam
+}
+
+// Fonction pour convertir une image PGM en image PAM GRAYSCALE
+func PAMFromPGM(img *PGM) *PAM {
+	width, height := img.Size()
+	pam := NewPAM(width, height, 1, img.MaxValue(), "GRAYSCALE")
+	for y := 0; y < height; y++ {
+		copy(pam.data[y], img.row(y))
+	}
+	return pam
+}
+
+// Fonction pour convertir une image PPM en image PAM RGB
+func PAMFromPPM(img *PPM) *PAM {
+	width, height := img.Size()
+	pam := NewPAM(width, height, 3, img.MaxValue(), "RGB")
+	for y := 0; y < height; y++ {
+		for x, p := range img.row(y) {
+			pam.data[y][3*x], pam.data[y][3*x+1], pam.data[y][3*x+2] = p.R, p.G, p.B
+		}
+	}
+	return pam
+}
@@ -0,0 +1,138 @@
+package netpbm
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestDecodePAMAlpha(t *testing.T) {
+	content := "P7\nWIDTH 2\nHEIGHT 1\nDEPTH 4\nMAXVAL 255\n# commentaire\nTUPLTYPE RGB_ALPHA\nENDHDR\n" +
+		"\xff\x00\x00\x80\x00\x00\xff\xff"
+	pam, err := DecodePAM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pam.Depth() != 4 || pam.TupleType() != "RGB_ALPHA" {
+		t.Errorf("Wrong header: depth %d, tuple type %q", pam.Depth(), pam.TupleType())
+	}
+	if c := pam.At(0, 0); c != (color.NRGBA64{R: 0xffff, A: 0x8080}) {
+		t.Errorf("Wrong color: %v", c)
+	}
+	samples := pam.Samples(1, 0)
+	if samples[2] != 255 || samples[3] != 255 {
+		t.Errorf("Wrong samples: %v", samples)
+	}
+
+	var buf bytes.Buffer
+	if err := pam.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "P7\nWIDTH 2\nHEIGHT 1\nDEPTH 4\nMAXVAL 255\nTUPLTYPE RGB_ALPHA\nENDHDR\n" +
+		"\xff\x00\x00\x80\x00\x00\xff\xff"
+	if buf.String() != want {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestDecodePAMErrors(t *testing.T) {
+	tests := []string{
+		"P7\nWIDTH 2\nHEIGHT 1\nMAXVAL 255\nENDHDR\n\x00\x00",
+		"P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 255\nFOO 1\nENDHDR\n\x00\x00",
+		"P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 255\nENDHDR\n\x00",
+	}
+	for _, content := range tests {
+		if _, err := DecodePAM(strings.NewReader(content)); err == nil {
+			t.Errorf("Expected an error for %q", content)
+		}
+	}
+}
+
+func TestPAMBlackAndWhite(t *testing.T) {
+	content := "P7\nWIDTH 3\nHEIGHT 1\nDEPTH 1\nMAXVAL 1\nTUPLTYPE BLACKANDWHITE\nENDHDR\n\x00\x01\x00"
+	pam, err := DecodePAM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbm := pam.ToPBM()
+	if !pbm.BitAt(0, 0) || pbm.BitAt(1, 0) || !pbm.BitAt(2, 0) {
+		t.Error("BLACKANDWHITE 0 should map to black")
+	}
+
+	var buf bytes.Buffer
+	if err := PAMFromPBM(pbm).Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != content {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPAMFromSubImage(t *testing.T) {
+	pgm := NewPGM(4, 4, 255, "P5")
+	ppm := NewPPM(4, 4, 255, "P6")
+	pbm := NewPBM(4, 4, "P4")
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			v := uint16(10 * (4*y + x))
+			pgm.Set(x, y, v)
+			ppm.Set(x, y, Pixel{R: v, G: v + 1, B: v + 2})
+			pbm.Set(x, y, x == y)
+		}
+	}
+	r := image.Rect(1, 1, 3, 3)
+
+	tests := []struct {
+		pam  *PAM
+		want string
+	}{
+		{PAMFromPGM(pgm.SubImage(r).(*PGM)), "2<Zd"},
+		{PAMFromPPM(ppm.SubImage(r).(*PPM)), "234<=>Z[\\def"},
+		{PAMFromPBM(pbm.SubImage(r).(*PBM)), "\x00\x01\x01\x00"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := test.pam.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		raster := buf.String()[strings.Index(buf.String(), "ENDHDR\n")+7:]
+		if raster != test.want {
+			t.Errorf("%s: wrong raster %q, want %q", test.pam.TupleType(), raster, test.want)
+		}
+	}
+}
+
+func TestNewPAMBounds(t *testing.T) {
+	pam := NewPAM(1, 1, 0, 0, "")
+	if pam.Depth() != 1 || pam.MaxValue() != 1 {
+		t.Errorf("Wrong header: depth %d, maxval %d", pam.Depth(), pam.MaxValue())
+	}
+	if c := pam.At(0, 0); c != (color.NRGBA64{A: 0xffff}) {
+		t.Errorf("Wrong color: %v", c)
+	}
+	if pam := NewPAM(1, 1, 1, 100000, ""); pam.MaxValue() != 65535 {
+		t.Errorf("Wrong maxval: %d", pam.MaxValue())
+	}
+
+	pam = NewPAM(2, 1, 3, 255, "RGB")
+	pam.SetSamples(2, 0, []uint16{1, 2, 3})
+	pam.SetSamples(-1, 0, []uint16{1, 2, 3})
+	pam.SetSamples(0, 1, []uint16{1, 2, 3})
+	for _, p := range []image.Point{{2, 0}, {-1, 0}, {0, 1}, {1, 0}} {
+		if samples := pam.Samples(p.X, p.Y); len(samples) != 3 || samples[0]|samples[1]|samples[2] != 0 {
+			t.Errorf("Wrong samples at %v: %v", p, samples)
+		}
+	}
+}
+
+func TestPAMEncodeSampleOutOfRange(t *testing.T) {
+	pam := NewPAM(2, 1, 2, 255, "GRAYSCALE_ALPHA")
+	pam.SetSamples(1, 0, []uint16{10, 256})
+	var buf bytes.Buffer
+	if err := pam.Encode(&buf); !errors.Is(err, ErrSampleOutOfRange) {
+		t.Errorf("got error %v, want ErrSampleOutOfRange", err)
+	}
+}
@@ -0,0 +1,462 @@
+package netpbm
+
+import (
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"strconv"
+
+	"Netbpm/internal/pnm"
+)
+
+// Définition de la structure PBM pour représenter une image PBM
+type PBM struct {
+	Pix         []byte          // Pixels compactés, huit par octet, bit de poids fort en premier (1 pour noir)
+	Stride      int             // Nombre d'octets entre deux lignes successives
+	Rect        image.Rectangle // Rectangle occupé par l'image
+	offset      int             // Position dans Pix[0] du bit du pixel Rect.Min, non nulle pour certaines sous-images
+	magicNumber string          // Numéro magique pour identifier le type de fichier PBM
+	comments    []string        // Commentaires de l'en-tête, dans l'ordre du fichier
+}
+
+// Fonction pour créer une image PBM blanche de la taille donnée. Chaque ligne
+// occupe ceil(width/8) octets, exactement comme dans un fichier P4.
+func NewPBM(width, height int, magicNumber string) *PBM {
+	stride := (width + 7) / 8
+	return &PBM{
+		Pix:         make([]byte, stride*height),
+		Stride:      stride,
+		Rect:        image.Rect(0, 0, width, height),
+		magicNumber: magicNumber,
+	}
+}
+
+// Fonction pour convertir une image quelconque en image PBM : les pixels dont
+// la luminance est inférieure à la moitié de la plage deviennent noirs
+func PBMFromImage(img image.Image, magicNumber string) *PBM {
+	bounds := img.Bounds()
+	pbm := NewPBM(bounds.Dx(), bounds.Dy(), magicNumber)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			gray := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			pbm.Set(x, y, gray.Y < 0x8000)
+		}
+	}
+	return pbm
+}
+
+// Fonction pour lire un fichier PBM et créer une instance PBM
+func ReadPBM(filename string) (*PBM, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodePBM(file)
+}
+
+// Fonction pour lire une image PBM depuis r et créer une instance PBM
+func DecodePBM(r io.Reader) (*PBM, error) {
+	return DecodePBMWithOptions(r, nil)
+}
+
+// Fonction pour lire une image PBM depuis r avec les options de décodage opts, qui peuvent être nil
+func DecodePBMWithOptions(r io.Reader, opts *DecodeOptions) (*PBM, error) {
+	rr, err := newPBMRowReader(pnm.NewReaderOptions(r, opts), false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Les lignes P4 sont lues telles quelles, les lignes P1 sont compactées
+	// une à une, ReadRow réutilisant sa tranche. Pix grandit au fil des
+	// lignes lues, voir rasterCap
+	pbm := NewPBM(0, 0, rr.magicNumber)
+	pbm.comments = rr.comments
+	pbm.Stride = (rr.width + 7) / 8
+	pbm.Pix = make([]byte, 0, rasterCap(pbm.Stride*rr.height))
+	packed := make([]byte, pbm.Stride)
+	for i := 0; i < rr.height; i++ {
+		if rr.magicNumber == "P4" {
+			if err := rr.readPacked(packed); err != nil {
+				return nil, err
+			}
+		} else {
+			row, err := rr.ReadRow()
+			if err != nil {
+				return nil, err
+			}
+			packP4Row(packed, row)
+		}
+		pbm.Pix = append(pbm.Pix, packed...)
+	}
+	if err := rr.hr.CheckTrailing("pbm"); err != nil {
+		return nil, err
+	}
+	pbm.Rect = image.Rect(0, 0, rr.width, rr.height)
+
+	// Renvoi de l'objet PBM
+	return pbm, nil
+}
+
+// Fonction pour lire l'en-tête PBM : numéro magique et dimensions
+func readPBMHeader(hr *pnm.Reader) (magicNumber string, width, height int, err error) {
+	// Lecture du numéro magique PBM
+	magicNumber, err = hr.Token()
+	if err != nil || (magicNumber != "P1" && magicNumber != "P4") {
+		return "", 0, 0, hr.HeaderError("pbm", pnm.ErrBadMagic, strconv.Quote(magicNumber))
+	}
+
+	// Lecture des dimensions de l'image
+	width, err = hr.Int()
+	if err != nil || width < 1 {
+		return "", 0, 0, hr.HeaderError("pbm", pnm.ErrBadHeader, "invalid width")
+	}
+
+	height, err = hr.Int()
+	if err != nil || height < 1 {
+		return "", 0, 0, hr.HeaderError("pbm", pnm.ErrBadHeader, "invalid height")
+	}
+	return magicNumber, width, height, nil
+}
+
+// Fonction pour lire uniquement l'en-tête d'une image PBM depuis r
+func decodePBMConfig(r io.Reader) (image.Config, error) {
+	_, width, height, err := readPBMHeader(pnm.NewReader(r))
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: color.GrayModel, Width: width, Height: height}, nil
+}
+
+// Enregistrement du format auprès du paquet image, pour image.Decode et image.DecodeConfig
+func init() {
+	decode := func(r io.Reader) (image.Image, error) {
+		pbm, err := DecodePBM(r)
+		if err != nil {
+			return nil, err
+		}
+		return pbm, nil
+	}
+	image.RegisterFormat("pbm", "P1", decode, decodePBMConfig)
+	image.RegisterFormat("pbm", "P4", decode, decodePBMConfig)
+}
+
+// Fonction pour lire la ligne y d'une image P1 dans le tableau booléen data ; en
+// mode Lenient, un caractère autre que 0 ou 1 est lu comme un pixel blanc
+func parseP1Row(hr *pnm.Reader, data []bool, y int) error {
+	for x := range data {
+		// Les pixels peuvent être séparés par des blancs ou accolés
+		c, err := hr.ReadByte()
+		for err == nil && pnm.IsSpace(c) {
+			c, err = hr.ReadByte()
+		}
+		if err != nil {
+			return hr.PixelError("pbm", x, y, pnm.ErrTruncated, "")
+		}
+		if c != '0' && c != '1' && hr.Mode() != pnm.Lenient {
+			return hr.PixelError("pbm", x, y, pnm.ErrBadSample, strconv.QuoteRune(rune(c)))
+		}
+		data[x] = c == '1'
+	}
+	return nil
+}
+
+// Fonction pour décompacter une ligne P4 (bit de poids fort en premier) dans le tableau booléen data
+func parseP4Row(data []bool, row []byte) {
+	// Parcours de la largeur de l'image, les bits de bourrage de fin de ligne sont ignorés
+	for i := range data {
+		// Calcul de l'index d'octet et de la position du bit dans l'octet
+		byteIndex := i / 8
+		bitPos := uint(7 - (i % 8))
+
+		// Extraction du bit de l'octet
+		bit := (row[byteIndex] >> bitPos) & 1
+		data[i] = bit == 1
+	}
+}
+
+// Méthode pour obtenir la taille de l'image PBM
+func (pbm *PBM) Size() (int, int) {
+	return pbm.Rect.Dx(), pbm.Rect.Dy()
+}
+
+// Méthode pour obtenir le numéro magique de l'image PBM
+func (pbm *PBM) Format() string {
+	return pbm.magicNumber
+}
+
+// Méthode pour obtenir les commentaires de l'en-tête de l'image PBM, dans l'ordre du fichier
+func (pbm *PBM) Comments() []string {
+	return append([]string(nil), pbm.comments...)
+}
+
+// Méthode pour définir les commentaires écrits dans l'en-tête par Save et Encode
+func (pbm *PBM) SetComments(comments []string) {
+	pbm.comments = append([]string(nil), comments...)
+}
+
+// Méthode pour obtenir la valeur d'une métadonnée key=valeur rangée dans les commentaires
+func (pbm *PBM) Metadata(key string) (string, bool) {
+	return pnm.Metadata(pbm.comments, key)
+}
+
+// Méthode pour ajouter ou remplacer le commentaire "key=value" de l'en-tête
+func (pbm *PBM) SetMetadata(key, value string) {
+	pbm.comments = pnm.SetMetadata(pbm.Comments(), key, value)
+}
+
+// Méthode pour obtenir la valeur maximale d'un échantillon, toujours 1 pour une image PBM
+func (pbm *PBM) MaxValue() int {
+	return 1
+}
+
+// Méthode pour obtenir les échantillons d'un pixel : 1 pour noir, 0 pour blanc
+func (pbm *PBM) Samples(x, y int) []uint16 {
+	if pbm.BitAt(x, y) {
+		return []uint16{1}
+	}
+	return []uint16{0}
+}
+
+// Méthode pour obtenir la position, en bits depuis le début de Pix, du pixel (x, y)
+func (pbm *PBM) bitIndex(x, y int) int {
+	return (y-pbm.Rect.Min.Y)*pbm.Stride*8 + (x - pbm.Rect.Min.X) + pbm.offset
+}
+
+// Méthode pour obtenir la valeur d'un pixel à une position spécifique dans l'image PBM
+func (pbm *PBM) BitAt(x, y int) bool {
+	if !(image.Point{x, y}.In(pbm.Rect)) {
+		return false
+	}
+	i := pbm.bitIndex(x, y)
+	return pbm.Pix[i/8]&(0x80>>uint(i%8)) != 0
+}
+
+// Méthode pour obtenir le modèle de couleur de l'image PBM
+func (pbm *PBM) ColorModel() color.Model {
+	return color.GrayModel
+}
+
+// Méthode pour obtenir le rectangle occupé par l'image PBM
+func (pbm *PBM) Bounds() image.Rectangle {
+	return pbm.Rect
+}
+
+// Méthode pour obtenir la couleur d'un pixel : noir pour 1, blanc pour 0
+func (pbm *PBM) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(pbm.Rect)) {
+		return color.Gray{}
+	}
+	if pbm.BitAt(x, y) {
+		return color.Gray{Y: 0}
+	}
+	return color.Gray{Y: 255}
+}
+
+// Méthode pour définir la valeur d'un pixel à une position spécifique dans
+// l'image PBM ; les positions hors de l'image sont ignorées
+func (pbm *PBM) Set(x, y int, value bool) {
+	if !(image.Point{x, y}.In(pbm.Rect)) {
+		return
+	}
+	i := pbm.bitIndex(x, y)
+	if value {
+		pbm.Pix[i/8] |= 0x80 >> uint(i%8)
+	} else {
+		pbm.Pix[i/8] &^= 0x80 >> uint(i%8)
+	}
+}
+
+// Méthode pour obtenir la partie de l'image PBM contenue dans r. L'image
+// renvoyée partage ses pixels avec l'image d'origine.
+func (pbm *PBM) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(pbm.Rect)
+	// Si r est vide, l'image renvoyée ne doit pas partager de pixels avec
+	// l'image d'origine, Pix[i/8:] pouvant sortir du tableau
+	if r.Empty() {
+		return &PBM{magicNumber: pbm.magicNumber, comments: pbm.comments}
+	}
+	i := pbm.bitIndex(r.Min.X, r.Min.Y)
+	return &PBM{
+		Pix:         pbm.Pix[i/8:],
+		Stride:      pbm.Stride,
+		Rect:        r,
+		offset:      i % 8,
+		magicNumber: pbm.magicNumber,
+		comments:    pbm.comments,
+	}
+}
+
+// Méthode pour décompacter la ligne y de l'image dans le tableau booléen row
+func (pbm *PBM) readRow(row []bool, y int) {
+	for x := range row {
+		row[x] = pbm.BitAt(pbm.Rect.Min.X+x, pbm.Rect.Min.Y+y)
+	}
+}
+
+// Méthode pour sauvegarder l'image PBM dans un fichier
+func (pbm *PBM) Save(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := pbm.Encode(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Méthode pour écrire l'image PBM dans w
+func (pbm *PBM) Encode(w io.Writer) error {
+	width, height := pbm.Size()
+	rw, err := NewPBMRowWriterComments(w, width, height, pbm.magicNumber, pbm.comments)
+	if err != nil {
+		return err
+	}
+
+	// Sans décalage, les lignes de Pix sont déjà au format P4
+	if pbm.magicNumber == "P4" && pbm.offset == 0 {
+		for y := 0; y < height; y++ {
+			if err := rw.writePacked(pbm.Pix[y*pbm.Stride : y*pbm.Stride+(width+7)/8]); err != nil {
+				return err
+			}
+		}
+		return rw.Close()
+	}
+
+	row := make([]bool, width)
+	for y := 0; y < height; y++ {
+		pbm.readRow(row, y)
+		if err := rw.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return rw.Close()
+}
+
+// Fonction pour compacter une ligne de pixels au format P4 dans row
+func packP4Row(row []byte, pixels []bool) {
+	for i := range row {
+		row[i] = 0
+	}
+	for i, pixel := range pixels {
+		if pixel {
+			row[i/8] |= 0x80 >> uint(i%8)
+		}
+	}
+}
+
+// Méthode pour inverser les couleurs de l'image PBM
+func (pbm *PBM) Invert() {
+	for y := pbm.Rect.Min.Y; y < pbm.Rect.Max.Y; y++ {
+		for x := pbm.Rect.Min.X; x < pbm.Rect.Max.X; x++ {
+			i := pbm.bitIndex(x, y)
+			pbm.Pix[i/8] ^= 0x80 >> uint(i%8)
+		}
+	}
+}
+
+// Méthode pour inverser les lignes de l'image PBM
+func (pbm *PBM) Flip() {
+	min, max := pbm.Rect.Min, pbm.Rect.Max
+	for y := min.Y; y < max.Y; y++ {
+		for x := 0; x < pbm.Rect.Dx()/2; x++ {
+			left, right := pbm.BitAt(min.X+x, y), pbm.BitAt(max.X-x-1, y)
+			pbm.Set(min.X+x, y, right)
+			pbm.Set(max.X-x-1, y, left)
+		}
+	}
+}
+
+// Méthode pour inverser les colonnes de l'image PBM
+func (pbm *PBM) Flop() {
+	min, max := pbm.Rect.Min, pbm.Rect.Max
+	for y := 0; y < pbm.Rect.Dy()/2; y++ {
+		for x := min.X; x < max.X; x++ {
+			top, bottom := pbm.BitAt(x, min.Y+y), pbm.BitAt(x, max.Y-y-1)
+			pbm.Set(x, min.Y+y, bottom)
+			pbm.Set(x, max.Y-y-1, top)
+		}
+	}
+}
+
+// Méthode pour faire pivoter l'image PBM de 90 degrés dans le sens des aiguilles d'une montre
+func (pbm *PBM) Rotate90CW() {
+	pbm.turn(turn90CW)
+}
+
+// Méthode pour faire pivoter l'image PBM de 90 degrés dans le sens inverse des aiguilles d'une montre
+func (pbm *PBM) Rotate90CCW() {
+	pbm.turn(turn90CCW)
+}
+
+// Méthode pour faire pivoter l'image PBM d'un demi-tour
+func (pbm *PBM) Rotate180() {
+	pbm.turn(turn180)
+}
+
+// Méthode pour refléter l'image PBM par rapport à sa diagonale principale,
+// qui va du coin supérieur gauche au coin inférieur droit
+func (pbm *PBM) Transpose() {
+	pbm.turn(transpose)
+}
+
+// Méthode pour refléter l'image PBM par rapport à son autre diagonale, qui va
+// du coin supérieur droit au coin inférieur gauche
+func (pbm *PBM) Transverse() {
+	pbm.turn(transverse)
+}
+
+// Méthode pour faire pivoter l'image PBM de degrees degrés dans le sens des
+// aiguilles d'une montre autour de son centre, en reprenant pour chaque pixel
+// le pixel d'origine le plus proche. L'image est agrandie pour contenir toute
+// l'image tournée, et les coins ajoutés sont noirs si background vaut true ; un
+// angle infini ou NaN laisse l'image inchangée
+func (pbm *PBM) RotateAngle(degrees float64, background bool) {
+	if t, ok := quarterTurns(degrees); ok {
+		if t.src != nil {
+			pbm.turn(t)
+		}
+		return
+	}
+	width, height := pbm.Size()
+	r := newRotation(width, height, degrees)
+	rotated := NewPBM(r.outWidth, r.outHeight, pbm.magicNumber)
+	for y := 0; y < r.outHeight; y++ {
+		for x := 0; x < r.outWidth; x++ {
+			u, v := r.source(x, y)
+			sx, _, _ := taps(Nearest, u)
+			sy, _, _ := taps(Nearest, v)
+			black := background
+			if sx >= 0 && sy >= 0 && sx < width && sy < height {
+				black = pbm.BitAt(pbm.Rect.Min.X+sx, pbm.Rect.Min.Y+sy)
+			}
+			rotated.Set(x, y, black)
+		}
+	}
+	pbm.Pix, pbm.Stride, pbm.Rect, pbm.offset = rotated.Pix, rotated.Stride, rotated.Rect, 0
+}
+
+// Méthode pour remplacer l'image PBM par sa transformée par t ; l'image
+// obtenue commence en (0, 0)
+func (pbm *PBM) turn(t quarterTurn) {
+	width, height := pbm.Size()
+	w, h := t.size(width, height)
+	rotated := NewPBM(w, h, pbm.magicNumber)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sx, sy := t.src(x, y, width, height)
+			rotated.Set(x, y, pbm.BitAt(pbm.Rect.Min.X+sx, pbm.Rect.Min.Y+sy))
+		}
+	}
+	pbm.Pix, pbm.Stride, pbm.Rect, pbm.offset = rotated.Pix, rotated.Stride, rotated.Rect, 0
+}
+
+// Méthode pour ajouter le première elementdu fichier
+func (pbm *PBM) SetMagicNumber(magicNumber string) {
+	pbm.magicNumber = magicNumber
+}
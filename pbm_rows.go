@@ -0,0 +1,244 @@
+package netpbm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"Netbpm/internal/pnm"
+)
+
+// Définition d'un lecteur PBM ligne par ligne, qui ne garde en mémoire qu'une
+// seule ligne de l'image
+type PBMRowReader struct {
+	hr            *pnm.Reader
+	magicNumber   string
+	comments      []string // Commentaires de l'en-tête
+	width, height int
+	y             int    // Indice de la prochaine ligne à lire
+	row           []bool // Ligne renvoyée par ReadRow, réutilisée d'un appel à l'autre
+	raw           []byte // Octets bruts d'une ligne P4
+	truncated     bool   // Fin des données atteinte en mode Lenient, les lignes suivantes sont blanches
+}
+
+// Fonction pour créer un lecteur ligne par ligne, après lecture de l'en-tête PBM
+func NewPBMRowReader(r io.Reader) (*PBMRowReader, error) {
+	return NewPBMRowReaderOptions(r, nil)
+}
+
+// Fonction pour créer un lecteur ligne par ligne avec les options de décodage opts, qui peuvent être nil
+func NewPBMRowReaderOptions(r io.Reader, opts *DecodeOptions) (*PBMRowReader, error) {
+	return newPBMRowReader(pnm.NewReaderOptions(r, opts), true)
+}
+
+// Fonction pour créer un lecteur à partir de hr ; rows indique une lecture ligne par ligne, où seule la largeur est limitée par défaut
+func newPBMRowReader(hr *pnm.Reader, rows bool) (*PBMRowReader, error) {
+	magicNumber, width, height, err := readPBMHeader(hr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vérification des limites avant toute allocation : un bit par pixel en
+	// P4, au moins un caractère en P1
+	bits := 8
+	if magicNumber == "P4" {
+		bits = 1
+	}
+	check := hr.CheckSize
+	if rows {
+		check = hr.CheckRowSize
+	}
+	if err := check("pbm", width, height, 1, bits); err != nil {
+		return nil, err
+	}
+	return &PBMRowReader{
+		hr:          hr,
+		magicNumber: magicNumber,
+		comments:    hr.Comments(),
+		width:       width,
+		height:      height,
+		row:         make([]bool, width),
+	}, nil
+}
+
+// Méthode pour obtenir la taille de l'image
+func (rr *PBMRowReader) Size() (int, int) {
+	return rr.width, rr.height
+}
+
+// Méthode pour obtenir les commentaires de l'en-tête, dans l'ordre du fichier
+func (rr *PBMRowReader) Comments() []string {
+	return rr.comments
+}
+
+// Méthode pour obtenir le numéro magique de l'image
+func (rr *PBMRowReader) Format() string {
+	return rr.magicNumber
+}
+
+// Méthode pour lire la ligne suivante. La tranche renvoyée est réutilisée par
+// l'appel suivant ; io.EOF est renvoyé une fois toutes les lignes lues.
+func (rr *PBMRowReader) ReadRow() ([]bool, error) {
+	if rr.y >= rr.height {
+		return nil, io.EOF
+	}
+
+	if rr.magicNumber == "P4" {
+		if rr.raw == nil {
+			rr.raw = make([]byte, (rr.width+7)/8)
+		}
+		if err := rr.readPacked(rr.raw); err != nil {
+			return nil, err
+		}
+		parseP4Row(rr.row, rr.raw)
+		return rr.row, nil
+	}
+
+	// Un caractère 0 ou 1 par pixel, les blancs et retours à la ligne étant
+	// ignorés ; en mode Lenient, les pixels manquants sont blancs
+	clear(rr.row)
+	if !rr.truncated {
+		err := parseP1Row(rr.hr, rr.row, rr.y)
+		if rr.hr.Recover(err) {
+			rr.truncated = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	rr.y++
+	return rr.row, nil
+}
+
+// Méthode pour lire la ligne P4 suivante sans la décompacter : chaque ligne
+// occupe ceil(width/8) octets, sans séparateur
+func (rr *PBMRowReader) readPacked(row []byte) error {
+	if rr.y >= rr.height {
+		return io.EOF
+	}
+	if rr.truncated {
+		clear(row)
+	} else {
+		truncated, err := rr.hr.ReadRaster("pbm", row, rr.y)
+		if err != nil {
+			return err
+		}
+		rr.truncated = truncated
+	}
+	rr.y++
+	return nil
+}
+
+// Définition d'un écrivain PBM ligne par ligne
+type PBMRowWriter struct {
+	writer        *bufio.Writer
+	magicNumber   string
+	width, height int
+	y             int    // Nombre de lignes déjà écrites
+	raw           []byte // Octets bruts d'une ligne P4
+}
+
+// Fonction pour créer un écrivain ligne par ligne et écrire l'en-tête PBM dans w
+func NewPBMRowWriter(w io.Writer, width, height int, magicNumber string) (*PBMRowWriter, error) {
+	return NewPBMRowWriterComments(w, width, height, magicNumber, nil)
+}
+
+// Fonction pour créer un écrivain ligne par ligne et écrire l'en-tête PBM dans w,
+// les commentaires comments étant écrits juste après le numéro magique
+func NewPBMRowWriterComments(w io.Writer, width, height int, magicNumber string, comments []string) (*PBMRowWriter, error) {
+	if magicNumber != "P1" && magicNumber != "P4" {
+		return nil, fmt.Errorf("%w: PBM magic number %q", pnm.ErrUnsupported, magicNumber)
+	}
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "%s\n", magicNumber)
+	if err := pnm.WriteComments(writer, comments); err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(writer, "%d %d\n", width, height); err != nil {
+		return nil, err
+	}
+	return &PBMRowWriter{
+		writer:      writer,
+		magicNumber: magicNumber,
+		width:       width,
+		height:      height,
+	}, nil
+}
+
+// Méthode pour écrire la ligne suivante, qui doit contenir width pixels
+func (rw *PBMRowWriter) WriteRow(row []bool) error {
+	if rw.y >= rw.height {
+		return errors.New("netpbm: pbm: all rows already written")
+	}
+	if len(row) != rw.width {
+		return fmt.Errorf("netpbm: pbm: row %d has %d pixels, want %d", rw.y, len(row), rw.width)
+	}
+
+	if rw.magicNumber == "P4" {
+		// Huit pixels par octet, bit de poids fort en premier, chaque ligne
+		// complétée jusqu'à l'octet suivant et sans séparateur
+		if rw.raw == nil {
+			rw.raw = make([]byte, (rw.width+7)/8)
+		}
+		packP4Row(rw.raw, row)
+		return rw.writePacked(rw.raw)
+	}
+
+	for _, pixel := range row {
+		if pixel {
+			rw.writer.WriteString("1 ")
+		} else {
+			rw.writer.WriteString("0 ")
+		}
+	}
+	if _, err := rw.writer.WriteString("\n"); err != nil {
+		return err
+	}
+	rw.y++
+	return nil
+}
+
+// Méthode pour écrire une ligne P4 déjà compactée ; les bits de bourrage de
+// fin de ligne sont écrits à 0
+func (rw *PBMRowWriter) writePacked(row []byte) error {
+	if rw.y >= rw.height {
+		return errors.New("netpbm: pbm: all rows already written")
+	}
+	if rw.raw == nil {
+		rw.raw = make([]byte, (rw.width+7)/8)
+	}
+	copy(rw.raw, row)
+	if rw.width%8 != 0 {
+		rw.raw[len(rw.raw)-1] &= 0xff << uint(8-rw.width%8)
+	}
+	if _, err := rw.writer.Write(rw.raw); err != nil {
+		return err
+	}
+	rw.y++
+	return nil
+}
+
+// Méthode pour terminer l'écriture : vide le tampon et vérifie que toutes les lignes ont été écrites
+func (rw *PBMRowWriter) Close() error {
+	if err := rw.writer.Flush(); err != nil {
+		return err
+	}
+	if rw.y != rw.height {
+		return fmt.Errorf("netpbm: pbm: only %d of %d rows written", rw.y, rw.height)
+	}
+	return nil
+}
+
+// Fonction pour inverser les couleurs d'une ligne de pixels
+func InvertPBMRow(row []bool) {
+	for x := range row {
+		row[x] = !row[x]
+	}
+}
+
+// Fonction pour inverser l'ordre des pixels d'une ligne
+func FlipPBMRow(row []bool) {
+	for x := 0; x < len(row)/2; x++ {
+		row[x], row[len(row)-x-1] = row[len(row)-x-1], row[x]
+	}
+}
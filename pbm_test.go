@@ -0,0 +1,401 @@
+package netpbm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"Netbpm/internal/pnm"
+)
+
+// writeTestFile écrit content dans un fichier temporaire et renvoie son chemin
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestReadPBMP4Raster(t *testing.T) {
+	// 10 pixels par ligne : deux octets, dont 6 bits de bourrage. Le premier
+	// octet vaut 0x0A, ce qui ne doit pas être pris pour une fin de ligne.
+	filename := writeTestFile(t, "p4.pbm", "P4\n# commentaire\n10 2\n\x0a\xc0\xff\xff")
+	pbm, err := ReadPBM(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]bool{
+		{false, false, false, false, true, false, true, false, true, true},
+		{true, true, true, true, true, true, true, true, true, true},
+	}
+	for y := range want {
+		for x := range want[y] {
+			if pbm.BitAt(x, y) != want[y][x] {
+				t.Errorf("Wrong data at (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+func TestReadPBMP4Truncated(t *testing.T) {
+	filename := writeTestFile(t, "p4.pbm", "P4 10 2\n\x0a\xc0\xff")
+	_, err := ReadPBM(filename)
+	if err == nil {
+		t.Fatal("Expected an error for a truncated raster")
+	}
+	var perr *pnm.ParseError
+	if !errors.Is(err, pnm.ErrTruncated) || !errors.As(err, &perr) || perr.Y != 1 || perr.Offset != 10 {
+		t.Errorf("Error should cite the row: %v", err)
+	}
+}
+
+func TestSavePBMP4Packed(t *testing.T) {
+	content := "P4\n10 2\n\x0a\xc0\xff\xc0"
+	pbm, err := ReadPBM(writeTestFile(t, "p4.pbm", content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "save.pbm")
+	if err := pbm.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != content {
+		t.Errorf("Wrong P4 output: got %q, want %q", saved, content)
+	}
+}
+
+func TestDecodeEncodePBM(t *testing.T) {
+	content := "P1\n3 2\n1 0 1 \n0 1 0 \n"
+	pbm, err := DecodePBM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := pbm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != content {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestRowReaderWriterPBM(t *testing.T) {
+	rr, err := NewPBMRowReader(strings.NewReader("P1\n3 2\n100\n011\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	rw, err := NewPBMRowWriter(&buf, 3, 2, "P4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		row, err := rr.ReadRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		InvertPBMRow(row)
+		FlipPBMRow(row)
+		if err := rw.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P4\n3 2\n\xc0\x20" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPBMSubImage(t *testing.T) {
+	pbm, err := DecodePBM(strings.NewReader("P1\n10 2\n1010101010\n0000011111\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pbm.Stride != 2 || len(pbm.Pix) != 4 {
+		t.Fatalf("Wrong storage: stride %d, %d bytes", pbm.Stride, len(pbm.Pix))
+	}
+
+	// La sous-image commence au milieu d'un octet et partage les pixels de pbm
+	sub := pbm.SubImage(image.Rect(3, 0, 8, 2)).(*PBM)
+	if sub.Bounds() != image.Rect(3, 0, 8, 2) || !sub.BitAt(4, 0) || sub.BitAt(3, 0) {
+		t.Errorf("Wrong sub-image: %v", sub.Bounds())
+	}
+	sub.Invert()
+	if pbm.BitAt(4, 0) || !pbm.BitAt(3, 0) || !pbm.BitAt(2, 0) {
+		t.Error("Sub-image does not share pixels with its parent")
+	}
+
+	var buf bytes.Buffer
+	sub.SetMagicNumber("P1")
+	if err := sub.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P1\n5 2\n1 0 1 0 1 \n1 1 0 0 0 \n" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPBMComments(t *testing.T) {
+	pbm, err := DecodePBM(strings.NewReader("P1\n# CREATOR: GIMP\n2 1\n1 0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comments := pbm.Comments(); len(comments) != 1 || comments[0] != "CREATOR: GIMP" {
+		t.Errorf("Wrong comments: %q", comments)
+	}
+
+	pbm.SetMagicNumber("P4")
+	var buf bytes.Buffer
+	if err := pbm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P4\n# CREATOR: GIMP\n2 1\n\x80" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPBMRotations(t *testing.T) {
+	tests := []struct {
+		name   string
+		rotate func(pbm *PBM)
+		want   string
+	}{
+		{"Rotate90CW", (*PBM).Rotate90CW, "P1\n2 3\n0 1 \n1 0 \n1 0 \n"},
+		{"Rotate90CCW", (*PBM).Rotate90CCW, "P1\n2 3\n0 1 \n0 1 \n1 0 \n"},
+		{"Rotate180", (*PBM).Rotate180, "P1\n3 2\n1 1 0 \n0 0 1 \n"},
+		{"Transpose", (*PBM).Transpose, "P1\n2 3\n1 0 \n0 1 \n0 1 \n"},
+		{"Transverse", (*PBM).Transverse, "P1\n2 3\n1 0 \n1 0 \n0 1 \n"},
+		{"RotateAngle(180)", func(pbm *PBM) { pbm.RotateAngle(180, true) }, "P1\n3 2\n1 1 0 \n0 0 1 \n"},
+	}
+	for _, test := range tests {
+		// La sous-image commence au milieu d'un octet
+		pbm, err := DecodePBM(strings.NewReader("P1\n5 2\n1 1 0 0 0\n0 0 1 1 1\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sub := pbm.SubImage(image.Rect(1, 0, 4, 2)).(*PBM)
+		sub.SetMagicNumber("P1")
+		test.rotate(sub)
+		var buf bytes.Buffer
+		if err := sub.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, buf.String(), test.want)
+		}
+	}
+}
+
+func TestPBMRotateAngle(t *testing.T) {
+	pbm := NewPBM(4, 4, "P1")
+	pbm.Invert()
+	pbm.RotateAngle(45, false)
+
+	// Le carré devient un losange, entouré de blanc
+	if w, h := pbm.Size(); w != 6 || h != 6 {
+		t.Fatalf("Wrong size: %dx%d", w, h)
+	}
+	var buf bytes.Buffer
+	if err := pbm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "P1\n6 6\n0 0 0 0 0 0 \n0 0 1 1 0 0 \n0 1 1 1 1 0 \n0 1 1 1 1 0 \n0 0 1 1 0 0 \n0 0 0 0 0 0 \n"
+	if buf.String() != want {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+// Image de test 15×15 des anciennes suites de tests, et les résultats
+// attendus de Invert, Flip et Flop
+const imageWidth = 15
+const imageHeight = 15
+
+var imageDataP1 = []bool{
+	false, false, false, false, false, false, false, true, true, true, true, false, false, false, false,
+	false, false, false, false, false, false, true, false, false, false, false, true, false, false, false,
+	false, false, false, false, false, true, false, false, false, false, false, false, true, false, false,
+	false, false, false, false, false, true, false, false, false, false, true, false, true, true, true,
+	false, false, false, false, false, true, false, false, false, false, false, false, false, false, true,
+	false, false, false, false, false, true, true, false, false, false, false, false, false, true, true,
+	true, true, false, false, false, false, true, true, false, false, false, true, true, true, false,
+	true, false, true, true, false, false, false, true, false, false, false, true, false, false, false,
+	true, false, false, false, true, true, true, false, false, false, false, true, false, false, false,
+	true, false, false, false, false, false, false, false, false, false, false, false, true, false, false,
+	true, false, false, false, false, false, false, false, false, false, false, false, true, false, false,
+	false, true, false, false, false, false, false, false, false, false, false, false, true, false, false,
+	false, true, true, false, false, false, false, false, false, false, false, true, false, false, false,
+	false, false, true, true, false, false, false, false, false, true, true, false, false, false, false,
+	false, false, false, false, true, true, true, true, true, true, false, false, false, false, false,
+}
+
+var imageDataInvert = []bool{
+	true, true, true, true, true, true, true, false, false, false, false, true, true, true, true,
+	true, true, true, true, true, true, false, true, true, true, true, false, true, true, true,
+	true, true, true, true, true, false, true, true, true, true, true, true, false, true, true,
+	true, true, true, true, true, false, true, true, true, true, false, true, false, false, false,
+	true, true, true, true, true, false, true, true, true, true, true, true, true, true, false,
+	true, true, true, true, true, false, false, true, true, true, true, true, true, false, false,
+	false, false, true, true, true, true, false, false, true, true, true, false, false, false, true,
+	false, true, false, false, true, true, true, false, true, true, true, false, true, true, true,
+	false, true, true, true, false, false, false, true, true, true, true, false, true, true, true,
+	false, true, true, true, true, true, true, true, true, true, true, true, false, true, true,
+	false, true, true, true, true, true, true, true, true, true, true, true, false, true, true,
+	true, false, true, true, true, true, true, true, true, true, true, true, false, true, true,
+	true, false, false, true, true, true, true, true, true, true, true, false, true, true, true,
+	true, true, false, false, true, true, true, true, true, false, false, true, true, true, true,
+	true, true, true, true, false, false, false, false, false, false, true, true, true, true, true,
+}
+
+var imageDataFlip = []bool{
+	false, false, false, false, true, true, true, true, false, false, false, false, false, false, false,
+	false, false, false, true, false, false, false, false, true, false, false, false, false, false, false,
+	false, false, true, false, false, false, false, false, false, true, false, false, false, false, false,
+	true, true, true, false, true, false, false, false, false, true, false, false, false, false, false,
+	true, false, false, false, false, false, false, false, false, true, false, false, false, false, false,
+	true, true, false, false, false, false, false, false, true, true, false, false, false, false, false,
+	false, true, true, true, false, false, false, true, true, false, false, false, false, true, true,
+	false, false, false, true, false, false, false, true, false, false, false, true, true, false, true,
+	false, false, false, true, false, false, false, false, true, true, true, false, false, false, true,
+	false, false, true, false, false, false, false, false, false, false, false, false, false, false, true,
+	false, false, true, false, false, false, false, false, false, false, false, false, false, false, true,
+	false, false, true, false, false, false, false, false, false, false, false, false, false, true, false,
+	false, false, false, true, false, false, false, false, false, false, false, false, true, true, false,
+	false, false, false, false, true, true, false, false, false, false, false, true, true, false, false,
+	false, false, false, false, false, true, true, true, true, true, true, false, false, false, false,
+}
+
+var imageDataFlop = []bool{
+	false, false, false, false, true, true, true, true, true, true, false, false, false, false, false,
+	false, false, true, true, false, false, false, false, false, true, true, false, false, false, false,
+	false, true, true, false, false, false, false, false, false, false, false, true, false, false, false,
+	false, true, false, false, false, false, false, false, false, false, false, false, true, false, false,
+	true, false, false, false, false, false, false, false, false, false, false, false, true, false, false,
+	true, false, false, false, false, false, false, false, false, false, false, false, true, false, false,
+	true, false, false, false, true, true, true, false, false, false, false, true, false, false, false,
+	true, false, true, true, false, false, false, true, false, false, false, true, false, false, false,
+	true, true, false, false, false, false, true, true, false, false, false, true, true, true, false,
+	false, false, false, false, false, true, true, false, false, false, false, false, false, true, true,
+	false, false, false, false, false, true, false, false, false, false, false, false, false, false, true,
+	false, false, false, false, false, true, false, false, false, false, true, false, true, true, true,
+	false, false, false, false, false, true, false, false, false, false, false, false, true, false, false,
+	false, false, false, false, false, false, true, false, false, false, false, true, false, false, false,
+	false, false, false, false, false, false, false, true, true, true, true, false, false, false, false,
+}
+
+// pbmFixture renvoie le contenu d'un fichier PBM contenant l'image data, au
+// format P1 ou P4.
+func pbmFixture(data []bool, magicNumber string) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n%d %d\n", magicNumber, imageWidth, imageHeight)
+	for y := 0; y < imageHeight; y++ {
+		row := data[y*imageWidth : (y+1)*imageWidth]
+		if magicNumber == "P4" {
+			packed := make([]byte, (imageWidth+7)/8)
+			for x, black := range row {
+				if black {
+					packed[x/8] |= 0x80 >> uint(x%8)
+				}
+			}
+			buf.Write(packed)
+			continue
+		}
+		for _, black := range row {
+			if black {
+				buf.WriteString("1 ")
+			} else {
+				buf.WriteString("0 ")
+			}
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// checkPBMData vérifie que pbm contient l'image data.
+func checkPBMData(t *testing.T, pbm *PBM, data []bool) {
+	t.Helper()
+	if w, h := pbm.Size(); w != imageWidth || h != imageHeight {
+		t.Fatalf("Wrong size %dx%d", w, h)
+	}
+	for i, want := range data {
+		if x, y := i%imageWidth, i/imageWidth; pbm.BitAt(x, y) != want {
+			t.Errorf("Wrong data at (%d, %d)", x, y)
+		}
+	}
+}
+
+func TestReadSavePBMFixture(t *testing.T) {
+	for _, magicNumber := range []string{"P1", "P4"} {
+		pbm, err := ReadPBM(writeTestFile(t, "test.pbm", pbmFixture(imageDataP1, magicNumber)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pbm.magicNumber != magicNumber {
+			t.Errorf("Wrong magic number %s", pbm.magicNumber)
+		}
+		checkPBMData(t, pbm, imageDataP1)
+		if !pbm.BitAt(0, 8) {
+			t.Error("Wrong value at (0, 8)")
+		}
+
+		filename := filepath.Join(t.TempDir(), "save.pbm")
+		if err := pbm.Save(filename); err != nil {
+			t.Fatal(err)
+		}
+		saved, err := ReadPBM(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.magicNumber != magicNumber {
+			t.Errorf("Wrong saved magic number %s", saved.magicNumber)
+		}
+		checkPBMData(t, saved, imageDataP1)
+	}
+}
+
+func TestPBMFixtureOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(pbm *PBM)
+		want []bool
+	}{
+		{"Invert", (*PBM).Invert, imageDataInvert},
+		{"Flip", (*PBM).Flip, imageDataFlip},
+		{"Flop", (*PBM).Flop, imageDataFlop},
+	}
+	for _, test := range tests {
+		pbm, err := DecodePBM(strings.NewReader(pbmFixture(imageDataP1, "P1")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.op(pbm)
+		t.Run(test.name, func(t *testing.T) { checkPBMData(t, pbm, test.want) })
+	}
+
+	pbm := NewPBM(imageWidth, imageHeight, "P1")
+	pbm.Set(1, 3, true)
+	if !pbm.BitAt(1, 3) {
+		t.Error("Set: wrong value")
+	}
+	pbm.SetMagicNumber("P4")
+	if pbm.magicNumber != "P4" {
+		t.Error("SetMagicNumber: wrong magic number")
+	}
+}
@@ -0,0 +1,313 @@
+package netpbm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+
+	"Netbpm/internal/pnm"
+)
+
+// Définition de la structure PFM pour représenter une image PFM (Portable FloatMap)
+type PFM struct {
+	data          [][]float32      // Valeurs de chaque ligne, de haut en bas, channels valeurs par pixel
+	width, height int              // Largeur et hauteur de l'image
+	channels      int              // 1 pour "Pf" (gris), 3 pour "PF" (RVB)
+	scale         float32          // Facteur d'échelle, toujours positif
+	byteOrder     binary.ByteOrder // Ordre des octets, donné par le signe du facteur d'échelle
+}
+
+// ToneMap ramène une valeur flottante quelconque dans l'intervalle [0, 1].
+type ToneMap func(v float32) float64
+
+// Fonction de tone mapping qui tronque simplement les valeurs à l'intervalle [0, 1]
+func Clamp(v float32) float64 {
+	return math.Max(0, math.Min(1, float64(v)))
+}
+
+// Fonction de tone mapping de Reinhard, v / (1 + v), qui conserve les détails des hautes lumières
+func Reinhard(v float32) float64 {
+	if v <= 0 {
+		return 0
+	}
+	return float64(v) / (1 + float64(v))
+}
+
+// Fonction pour créer une image PFM noire, "Pf" pour du gris ou "PF" pour du RVB
+func NewPFM(width, height int, magicNumber string) (*PFM, error) {
+	channels, err := channelsOf(magicNumber)
+	if err != nil {
+		return nil, err
+	}
+	data := make([][]float32, height)
+	for i := range data {
+		data[i] = make([]float32, width*channels)
+	}
+	return &PFM{
+		data:      data,
+		width:     width,
+		height:    height,
+		channels:  channels,
+		scale:     1,
+		byteOrder: binary.LittleEndian,
+	}, nil
+}
+
+// Fonction pour obtenir le nombre de valeurs par pixel associé au numéro magique
+func channelsOf(magicNumber string) (int, error) {
+	switch magicNumber {
+	case "Pf":
+		return 1, nil
+	case "PF":
+		return 3, nil
+	}
+	return 0, fmt.Errorf("%w: PFM magic number %q", pnm.ErrUnsupported, magicNumber)
+}
+
+// Fonction pour lire un fichier PFM et créer une instance PFM
+func ReadPFM(filename string) (*PFM, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodePFM(file)
+}
+
+// Fonction pour lire une image PFM depuis r et créer une instance PFM
+func DecodePFM(r io.Reader) (*PFM, error) {
+	return DecodePFMWithOptions(r, nil)
+}
+
+// Fonction pour lire une image PFM depuis r avec les options de décodage opts,
+// qui peuvent être nil ; en mode Lenient, les lignes manquantes valent 0
+func DecodePFMWithOptions(r io.Reader, opts *DecodeOptions) (*PFM, error) {
+	hr := pnm.NewReaderOptions(r, opts)
+	magicNumber, err := hr.Token()
+	if err != nil {
+		return nil, hr.HeaderError("pfm", pnm.ErrBadMagic, "")
+	}
+	channels, err := channelsOf(magicNumber)
+	if err != nil {
+		return nil, hr.HeaderError("pfm", pnm.ErrBadMagic, strconv.Quote(magicNumber))
+	}
+
+	width, err := hr.Int()
+	if err != nil || width < 1 {
+		return nil, hr.HeaderError("pfm", pnm.ErrBadHeader, "invalid width")
+	}
+	height, err := hr.Int()
+	if err != nil || height < 1 {
+		return nil, hr.HeaderError("pfm", pnm.ErrBadHeader, "invalid height")
+	}
+
+	// Le signe du facteur d'échelle donne l'ordre des octets : négatif pour little endian
+	token, err := hr.Token()
+	if err != nil {
+		return nil, hr.HeaderError("pfm", pnm.ErrBadHeader, "invalid scale")
+	}
+	scale, err := strconv.ParseFloat(token, 32)
+	if err != nil || scale == 0 {
+		return nil, hr.HeaderError("pfm", pnm.ErrBadHeader, "invalid scale")
+	}
+	var byteOrder binary.ByteOrder = binary.BigEndian
+	if scale < 0 {
+		byteOrder = binary.LittleEndian
+	}
+	if err := hr.CheckSize("pfm", width, height, channels, 32); err != nil {
+		return nil, err
+	}
+
+	// Les lignes sont stockées de bas en haut ; chaque ligne n'est allouée
+	// qu'une fois lue (voir rasterCap), puis l'ordre des lignes est inversé
+	row := make([]byte, 4*width*channels)
+	data := make([][]float32, 0, rasterCap(height))
+	truncated := false
+	for i := height - 1; i >= 0; i-- {
+		values := make([]float32, width*channels)
+		data = append(data, values)
+		if truncated {
+			continue
+		}
+		if truncated, err = hr.ReadRaster("pfm", row, i); err != nil {
+			return nil, err
+		}
+		for j := range values {
+			values[j] = math.Float32frombits(byteOrder.Uint32(row[4*j:]))
+		}
+	}
+	if err := hr.CheckTrailing("pfm"); err != nil {
+		return nil, err
+	}
+	slices.Reverse(data)
+
+	return &PFM{
+		data:      data,
+		width:     width,
+		height:    height,
+		channels:  channels,
+		scale:     float32(math.Abs(scale)),
+		byteOrder: byteOrder,
+	}, nil
+}
+
+// Méthode pour obtenir la taille de l'image PFM
+func (pfm *PFM) Size() (int, int) {
+	return pfm.width, pfm.height
+}
+
+// Méthode pour obtenir le numéro magique de l'image PFM
+func (pfm *PFM) Format() string {
+	if pfm.channels == 1 {
+		return "Pf"
+	}
+	return "PF"
+}
+
+// Méthode pour obtenir le nombre de valeurs par pixel, 1 ou 3
+func (pfm *PFM) Channels() int {
+	return pfm.channels
+}
+
+// Méthode pour obtenir le facteur d'échelle de l'image PFM
+func (pfm *PFM) Scale() float32 {
+	return pfm.scale
+}
+
+// Méthode pour définir le facteur d'échelle, dont seule la valeur absolue est conservée
+func (pfm *PFM) SetScale(scale float32) {
+	pfm.scale = float32(math.Abs(float64(scale)))
+}
+
+// Méthode pour obtenir l'ordre des octets utilisé à l'écriture
+func (pfm *PFM) ByteOrder() binary.ByteOrder {
+	return pfm.byteOrder
+}
+
+// Méthode pour définir l'ordre des octets, binary.LittleEndian ou binary.BigEndian.
+// Un autre ordre, comme binary.NativeEndian, est remplacé par celui des deux
+// qui range les octets de la même façon ; nil est ignoré
+func (pfm *PFM) SetByteOrder(byteOrder binary.ByteOrder) {
+	if byteOrder == nil {
+		return
+	}
+	var probe [4]byte
+	byteOrder.PutUint32(probe[:], 1)
+	if probe[0] == 1 {
+		pfm.byteOrder = binary.LittleEndian
+	} else {
+		pfm.byteOrder = binary.BigEndian
+	}
+}
+
+// Méthode pour obtenir les valeurs d'un pixel
+func (pfm *PFM) FloatAt(x, y int) []float32 {
+	values := make([]float32, pfm.channels)
+	copy(values, pfm.data[y][x*pfm.channels:])
+	return values
+}
+
+// Méthode pour définir les valeurs d'un pixel
+func (pfm *PFM) Set(x, y int, values []float32) {
+	copy(pfm.data[y][x*pfm.channels:(x+1)*pfm.channels], values)
+}
+
+// Méthode pour sauvegarder l'image PFM dans un fichier
+func (pfm *PFM) Save(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := pfm.Encode(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Méthode pour écrire l'image PFM dans w
+func (pfm *PFM) Encode(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	// Le facteur d'échelle est écrit négatif pour signaler du little endian
+	scale := pfm.scale
+	if pfm.byteOrder == binary.LittleEndian {
+		scale = -scale
+	}
+	fmt.Fprintf(writer, "%s\n%d %d\n%s\n", pfm.Format(), pfm.width, pfm.height,
+		strconv.FormatFloat(float64(scale), 'f', -1, 32))
+
+	row := make([]byte, 4*pfm.width*pfm.channels)
+	for i := pfm.height - 1; i >= 0; i-- {
+		for j, v := range pfm.data[i] {
+			pfm.byteOrder.PutUint32(row[4*j:], math.Float32bits(v))
+		}
+		writer.Write(row)
+	}
+	return writer.Flush()
+}
+
+// Méthode pour convertir l'image PFM en image PGM : la luminance de chaque
+// pixel passe par toneMap (Clamp si nil) puis est ramenée sur 0..maxValue,
+// maxValue étant elle-même ramenée dans la plage 1..65535
+func (pfm *PFM) ToPGM(maxValue int, toneMap ToneMap) *PGM {
+	if toneMap == nil {
+		toneMap = Clamp
+	}
+	maxValue = clampMaxValue(maxValue)
+	img := NewPGM(pfm.width, pfm.height, maxValue, "P5")
+	for y := 0; y < pfm.height; y++ {
+		for x := 0; x < pfm.width; x++ {
+			v := pfm.data[y][x*pfm.channels]
+			if pfm.channels == 3 {
+				r, g, b := pfm.data[y][3*x], pfm.data[y][3*x+1], pfm.data[y][3*x+2]
+				v = 0.2126*r + 0.7152*g + 0.0722*b
+			}
+			img.Set(x, y, quantize(toneMap(v), maxValue))
+		}
+	}
+	return img
+}
+
+// Méthode pour convertir l'image PFM en image PPM : chaque composante passe
+// par toneMap (Clamp si nil) puis est ramenée sur 0..maxValue, maxValue étant
+// elle-même ramenée dans la plage 1..65535
+func (pfm *PFM) ToPPM(maxValue int, toneMap ToneMap) *PPM {
+	if toneMap == nil {
+		toneMap = Clamp
+	}
+	maxValue = clampMaxValue(maxValue)
+	img := NewPPM(pfm.width, pfm.height, maxValue, "P6")
+	for y := 0; y < pfm.height; y++ {
+		for x := 0; x < pfm.width; x++ {
+			values := pfm.data[y][x*pfm.channels : (x+1)*pfm.channels]
+			if pfm.channels == 1 {
+				v := quantize(toneMap(values[0]), maxValue)
+				img.Set(x, y, Pixel{R: v, G: v, B: v})
+				continue
+			}
+			img.Set(x, y, Pixel{
+				R: quantize(toneMap(values[0]), maxValue),
+				G: quantize(toneMap(values[1]), maxValue),
+				B: quantize(toneMap(values[2]), maxValue),
+			})
+		}
+	}
+	return img
+}
+
+// Fonction pour ramener une valeur de [0, 1] sur la plage entière 0..maxValue
+func quantize(v float64, maxValue int) uint16 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	v = math.Max(0, math.Min(1, v))
+	return uint16(math.Round(v * float64(maxValue)))
+}
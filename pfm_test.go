@@ -0,0 +1,110 @@
+package netpbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestDecodePFMLittleEndian(t *testing.T) {
+	// Deux lignes d'un pixel gris : la première ligne du fichier est celle du bas
+	content := "Pf\n1 2\n-1.0\n\x00\x00\x80\x3f\x00\x00\x00\x40"
+	pfm, err := DecodePFM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pfm.ByteOrder() != binary.LittleEndian || pfm.Scale() != 1 {
+		t.Errorf("Wrong scale: %v %v", pfm.ByteOrder(), pfm.Scale())
+	}
+	if pfm.FloatAt(0, 0)[0] != 2 || pfm.FloatAt(0, 1)[0] != 1 {
+		t.Errorf("Wrong values: %v %v", pfm.FloatAt(0, 0), pfm.FloatAt(0, 1))
+	}
+
+	var buf bytes.Buffer
+	if err := pfm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "Pf\n1 2\n-1\n\x00\x00\x80\x3f\x00\x00\x00\x40" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestDecodePFMBigEndian(t *testing.T) {
+	content := "PF\n1 1\n2.5\n\x3f\x80\x00\x00\x00\x00\x00\x00\x3f\x00\x00\x00"
+	pfm, err := DecodePFM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pfm.ByteOrder() != binary.BigEndian || pfm.Scale() != 2.5 || pfm.Format() != "PF" {
+		t.Errorf("Wrong header: %v %v %s", pfm.ByteOrder(), pfm.Scale(), pfm.Format())
+	}
+	values := pfm.FloatAt(0, 0)
+	if values[0] != 1 || values[1] != 0 || values[2] != 0.5 {
+		t.Errorf("Wrong values: %v", values)
+	}
+
+	ppm := pfm.ToPPM(255, nil)
+	if p := ppm.PixelAt(0, 0); p.R != 255 || p.G != 0 || p.B != 128 {
+		t.Errorf("Wrong tone mapped pixel: %v", p)
+	}
+	pgm := pfm.ToPGM(100, Reinhard)
+	if v := pgm.GrayAt(0, 0); v != 20 {
+		t.Errorf("Wrong tone mapped gray: %d", v)
+	}
+}
+
+func TestDecodePFMTruncated(t *testing.T) {
+	if _, err := DecodePFM(strings.NewReader("Pf\n1 2\n-1.0\n\x00\x00\x80\x3f")); err == nil {
+		t.Error("Expected an error for a truncated raster")
+	}
+	if _, err := DecodePFM(strings.NewReader("Pf\n1 1\n0\n\x00\x00\x80\x3f")); err == nil {
+		t.Error("Expected an error for a zero scale")
+	}
+}
+
+func TestPFMSetByteOrder(t *testing.T) {
+	content := "Pf\n1 1\n-1\n\x00\x00\x80\x3f"
+	for _, byteOrder := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian, binary.NativeEndian} {
+		pfm, err := DecodePFM(strings.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pfm.SetByteOrder(byteOrder)
+		if pfm.ByteOrder() != binary.LittleEndian && pfm.ByteOrder() != binary.BigEndian {
+			t.Errorf("%v: byte order not normalised: %v", byteOrder, pfm.ByteOrder())
+		}
+
+		// Le signe du facteur d'échelle doit correspondre aux octets écrits
+		var buf bytes.Buffer
+		if err := pfm.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodePFM(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := decoded.FloatAt(0, 0)[0]; v != 1 {
+			t.Errorf("%v: wrong value after encoding: %v", byteOrder, v)
+		}
+	}
+}
+
+func TestPFMToneMapMaxValue(t *testing.T) {
+	pfm, err := DecodePFM(strings.NewReader("Pf\n1 1\n-1\n\x00\x00\x80\x3f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Une valeur maximale hors de 1..65535 est ramenée dans la plage avant
+	// la quantification : le blanc reste blanc
+	for _, test := range []struct{ maxValue, want int }{{100000, 65535}, {0, 1}, {-5, 1}} {
+		pgm := pfm.ToPGM(test.maxValue, nil)
+		if pgm.MaxValue() != test.want || int(pgm.GrayAt(0, 0)) != test.want {
+			t.Errorf("ToPGM(%d): maxval %d, gray %d", test.maxValue, pgm.MaxValue(), pgm.GrayAt(0, 0))
+		}
+		ppm := pfm.ToPPM(test.maxValue, nil)
+		if p := ppm.PixelAt(0, 0); ppm.MaxValue() != test.want || int(p.R) != test.want {
+			t.Errorf("ToPPM(%d): maxval %d, pixel %v", test.maxValue, ppm.MaxValue(), p)
+		}
+	}
+}
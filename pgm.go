@@ -0,0 +1,423 @@
+package netpbm
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"strconv"
+
+	"Netbpm/internal/pnm"
+)
+
+// Définition de la structure PGM pour représenter une image PGM
+type PGM struct {
+	Pix         []uint16        // Niveaux de gris, ligne après ligne, dans un seul tableau
+	Stride      int             // Écart dans Pix entre deux pixels verticalement adjacents
+	Rect        image.Rectangle // Rectangle occupé par l'image
+	magicNumber string          // Numéro magique pour identifier le type de fichier PGM
+	max         int             // Valeur maximale autorisée pour un pixel
+	comments    []string        // Commentaires de l'en-tête, dans l'ordre du fichier
+}
+
+// Fonction pour créer une image PGM noire de la taille et de la valeur maximale données ;
+// maxValue est ramenée dans la plage 1..65535 autorisée par le format
+func NewPGM(width, height, maxValue int, magicNumber string) *PGM {
+	maxValue = clampMaxValue(maxValue)
+	return &PGM{
+		Pix:         make([]uint16, width*height),
+		Stride:      width,
+		Rect:        image.Rect(0, 0, width, height),
+		magicNumber: magicNumber,
+		max:         maxValue,
+	}
+}
+
+// Fonction pour convertir une image quelconque en image PGM, les niveaux de
+// gris étant ramenés sur la plage 0..maxValue ; comme pour NewPGM, maxValue
+// est ramenée dans la plage 1..65535
+func PGMFromImage(img image.Image, maxValue int, magicNumber string) *PGM {
+	bounds := img.Bounds()
+	maxValue = clampMaxValue(maxValue)
+	pgm := NewPGM(bounds.Dx(), bounds.Dy(), maxValue, magicNumber)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			gray := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			pgm.Pix[y*pgm.Stride+x] = scaleSample(gray.Y, maxValue)
+		}
+	}
+	return pgm
+}
+
+// Fonction pour ramener une valeur maximale dans la plage 1..65535 des formats PGM et PPM
+func clampMaxValue(maxValue int) int {
+	return min(max(maxValue, 1), 65535)
+}
+
+// Fonction pour ramener un échantillon 16 bits sur la plage 0..maxValue, en arrondissant
+func scaleSample(v uint16, maxValue int) uint16 {
+	return uint16((uint32(v)*uint32(maxValue) + 0x7fff) / 0xffff)
+}
+
+// Fonction pour lire un fichier PGM et créer une instance PGM
+func ReadPGM(filename string) (*PGM, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodePGM(file)
+}
+
+// Fonction pour lire une image PGM depuis r et créer une instance PGM
+func DecodePGM(r io.Reader) (*PGM, error) {
+	return DecodePGMWithOptions(r, nil)
+}
+
+// Fonction pour lire une image PGM depuis r avec les options de décodage opts, qui peuvent être nil
+func DecodePGMWithOptions(r io.Reader, opts *DecodeOptions) (*PGM, error) {
+	rr, err := newPGMRowReader(pnm.NewReaderOptions(r, opts), false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Toutes les lignes sont recopiées à la suite dans un seul tableau, qui
+	// grandit au fil des lignes lues (voir rasterCap)
+	pgm := NewPGM(0, 0, rr.max, rr.magicNumber)
+	pgm.comments = rr.comments
+	pgm.Stride = rr.width
+	pgm.Pix = make([]uint16, 0, rasterCap(rr.width*rr.height))
+	for i := 0; i < rr.height; i++ {
+		row, err := rr.ReadRow()
+		if err != nil {
+			return nil, err
+		}
+		pgm.Pix = append(pgm.Pix, row...)
+	}
+	if err := rr.hr.CheckTrailing("pgm"); err != nil {
+		return nil, err
+	}
+	pgm.Rect = image.Rect(0, 0, rr.width, rr.height)
+	return pgm, nil
+}
+
+// Fonction pour lire l'en-tête PGM : numéro magique, dimensions et valeur maximale
+func readPGMHeader(hr *pnm.Reader) (magicNumber string, width, height, maxVal int, err error) {
+	magicNumber, err = hr.Token()
+	if err != nil || (magicNumber != "P2" && magicNumber != "P5") {
+		return "", 0, 0, 0, hr.HeaderError("pgm", pnm.ErrBadMagic, strconv.Quote(magicNumber))
+	}
+
+	width, err = hr.Int()
+	if err != nil || width < 1 {
+		return "", 0, 0, 0, hr.HeaderError("pgm", pnm.ErrBadHeader, "invalid width")
+	}
+	height, err = hr.Int()
+	if err != nil || height < 1 {
+		return "", 0, 0, 0, hr.HeaderError("pgm", pnm.ErrBadHeader, "invalid height")
+	}
+	maxVal, err = hr.Int()
+	if err != nil || maxVal < 1 {
+		return "", 0, 0, 0, hr.HeaderError("pgm", pnm.ErrBadHeader, "invalid max value")
+	}
+
+	if maxVal > 65535 {
+		return "", 0, 0, 0, hr.HeaderError("pgm", pnm.ErrUnsupported, fmt.Sprintf("max value %d", maxVal))
+	}
+	return magicNumber, width, height, maxVal, nil
+}
+
+// Fonction pour lire uniquement l'en-tête d'une image PGM depuis r
+func decodePGMConfig(r io.Reader) (image.Config, error) {
+	_, width, height, maxVal, err := readPGMHeader(pnm.NewReader(r))
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: pgmColorModel(maxVal), Width: width, Height: height}, nil
+}
+
+// Enregistrement du format auprès du paquet image, pour image.Decode et image.DecodeConfig
+func init() {
+	decode := func(r io.Reader) (image.Image, error) {
+		pgm, err := DecodePGM(r)
+		if err != nil {
+			return nil, err
+		}
+		return pgm, nil
+	}
+	image.RegisterFormat("pgm", "P2", decode, decodePGMConfig)
+	image.RegisterFormat("pgm", "P5", decode, decodePGMConfig)
+}
+
+// Fonction pour choisir le modèle de couleur : 8 bits si la valeur maximale est 255, 16 bits sinon
+func pgmColorModel(maxVal int) color.Model {
+	if maxVal == 255 {
+		return color.GrayModel
+	}
+	return color.Gray16Model
+}
+
+// Méthode pour obtenir la taille de l'image PGM
+func (pgm *PGM) Size() (int, int) {
+	return pgm.Rect.Dx(), pgm.Rect.Dy()
+}
+
+// Méthode pour obtenir le numéro magique de l'image PGM
+func (pgm *PGM) Format() string {
+	return pgm.magicNumber
+}
+
+// Méthode pour obtenir les commentaires de l'en-tête de l'image PGM, dans l'ordre du fichier
+func (pgm *PGM) Comments() []string {
+	return append([]string(nil), pgm.comments...)
+}
+
+// Méthode pour définir les commentaires écrits dans l'en-tête par Save et Encode
+func (pgm *PGM) SetComments(comments []string) {
+	pgm.comments = append([]string(nil), comments...)
+}
+
+// Méthode pour obtenir la valeur d'une métadonnée key=valeur rangée dans les commentaires
+func (pgm *PGM) Metadata(key string) (string, bool) {
+	return pnm.Metadata(pgm.comments, key)
+}
+
+// Méthode pour ajouter ou remplacer le commentaire "key=value" de l'en-tête
+func (pgm *PGM) SetMetadata(key, value string) {
+	pgm.comments = pnm.SetMetadata(pgm.Comments(), key, value)
+}
+
+// Méthode pour obtenir la valeur maximale autorisée pour un pixel
+func (pgm *PGM) MaxValue() int {
+	return pgm.max
+}
+
+// Méthode pour obtenir les échantillons d'un pixel, ici son seul niveau de gris
+func (pgm *PGM) Samples(x, y int) []uint16 {
+	return []uint16{pgm.GrayAt(x, y)}
+}
+
+// Méthode pour obtenir l'indice dans Pix du pixel (x, y)
+func (pgm *PGM) PixOffset(x, y int) int {
+	return (y-pgm.Rect.Min.Y)*pgm.Stride + (x - pgm.Rect.Min.X)
+}
+
+// Méthode pour obtenir la ligne y de l'image, qui partage la mémoire de Pix
+func (pgm *PGM) row(y int) []uint16 {
+	i := pgm.PixOffset(pgm.Rect.Min.X, pgm.Rect.Min.Y+y)
+	return pgm.Pix[i : i+pgm.Rect.Dx()]
+}
+
+// Méthode pour obtenir la valeur d'un pixel à une position spécifique dans l'image PGM
+func (pgm *PGM) GrayAt(x, y int) uint16 {
+	if !(image.Point{x, y}.In(pgm.Rect)) {
+		return 0
+	}
+	return pgm.Pix[pgm.PixOffset(x, y)]
+}
+
+// Méthode pour obtenir le modèle de couleur de l'image PGM
+func (pgm *PGM) ColorModel() color.Model {
+	return pgmColorModel(pgm.max)
+}
+
+// Méthode pour obtenir le rectangle occupé par l'image PGM
+func (pgm *PGM) Bounds() image.Rectangle {
+	return pgm.Rect
+}
+
+// Méthode pour obtenir la couleur d'un pixel, ramenée sur toute la plage 8 ou 16 bits
+func (pgm *PGM) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(pgm.Rect)) {
+		return color.Gray{}
+	}
+	v := pgm.Pix[pgm.PixOffset(x, y)]
+	if pgm.max == 255 {
+		return color.Gray{Y: uint8(v)}
+	}
+	return color.Gray16{Y: uint16(uint32(v) * 0xffff / uint32(pgm.max))}
+}
+
+// Méthode pour définir la valeur d'un pixel à une position spécifique dans
+// l'image PGM ; les positions hors de l'image sont ignorées
+func (pgm *PGM) Set(x, y int, value uint16) {
+	if !(image.Point{x, y}.In(pgm.Rect)) {
+		return
+	}
+	pgm.Pix[pgm.PixOffset(x, y)] = value
+}
+
+// Méthode pour obtenir la partie de l'image PGM contenue dans r. L'image
+// renvoyée partage ses pixels avec l'image d'origine.
+func (pgm *PGM) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(pgm.Rect)
+	// Si r est vide, l'image renvoyée ne doit pas partager de pixels avec
+	// l'image d'origine, Pix[i:] pouvant sortir du tableau
+	if r.Empty() {
+		return &PGM{magicNumber: pgm.magicNumber, max: pgm.max, comments: pgm.comments}
+	}
+	i := pgm.PixOffset(r.Min.X, r.Min.Y)
+	return &PGM{
+		Pix:         pgm.Pix[i:],
+		Stride:      pgm.Stride,
+		Rect:        r,
+		magicNumber: pgm.magicNumber,
+		max:         pgm.max,
+		comments:    pgm.comments,
+	}
+}
+
+// Méthode pour sauvegarder l'image PGM dans un fichier
+func (pgm *PGM) Save(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := pgm.Encode(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Méthode pour écrire l'image PGM dans w
+func (pgm *PGM) Encode(w io.Writer) error {
+	width, height := pgm.Size()
+	rw, err := NewPGMRowWriterComments(w, width, height, pgm.max, pgm.magicNumber, pgm.comments)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < height; i++ {
+		if err := rw.WriteRow(pgm.row(i)); err != nil {
+			return err
+		}
+	}
+	return rw.Close()
+}
+
+// Méthode pour inverser les couleurs de l'image PGM
+func (pgm *PGM) Invert() {
+	for i := 0; i < pgm.Rect.Dy(); i++ {
+		InvertPGMRow(pgm.row(i), pgm.max)
+	}
+}
+
+// Méthode pour inverser les lignes de l'image PGM
+func (pgm *PGM) Flip() {
+	for i := 0; i < pgm.Rect.Dy(); i++ {
+		FlipPGMRow(pgm.row(i))
+	}
+}
+
+// Méthode pour inverser les colonnes de l'image PGM
+func (pgm *PGM) Flop() {
+	height := pgm.Rect.Dy()
+	for i := 0; i < height/2; i++ {
+		top, bottom := pgm.row(i), pgm.row(height-i-1)
+		for j := range top {
+			top[j], bottom[j] = bottom[j], top[j]
+		}
+	}
+}
+
+// Méthode pour définir le numéro magique de l'image PGM
+func (pgm *PGM) SetMagicNumber(magicNumber string) {
+	pgm.magicNumber = magicNumber
+}
+
+// Méthode pour définir la valeur maximale autorisée pour un pixel dans l'image PGM ; 0 vaut 1
+func (pgm *PGM) SetMaxValue(maxValue uint16) {
+	pgm.max = clampMaxValue(int(maxValue))
+}
+
+// Méthode pour faire pivoter l'image PGM de 90 degrés dans le sens des aiguilles d'une montre
+func (pgm *PGM) Rotate90CW() {
+	pgm.turn(turn90CW)
+}
+
+// Méthode pour faire pivoter l'image PGM de 90 degrés dans le sens inverse des aiguilles d'une montre
+func (pgm *PGM) Rotate90CCW() {
+	pgm.turn(turn90CCW)
+}
+
+// Méthode pour faire pivoter l'image PGM d'un demi-tour
+func (pgm *PGM) Rotate180() {
+	pgm.turn(turn180)
+}
+
+// Méthode pour refléter l'image PGM par rapport à sa diagonale principale,
+// qui va du coin supérieur gauche au coin inférieur droit
+func (pgm *PGM) Transpose() {
+	pgm.turn(transpose)
+}
+
+// Méthode pour refléter l'image PGM par rapport à son autre diagonale, qui va
+// du coin supérieur droit au coin inférieur gauche
+func (pgm *PGM) Transverse() {
+	pgm.turn(transverse)
+}
+
+// Méthode pour faire pivoter l'image PGM de degrees degrés dans le sens des
+// aiguilles d'une montre autour de son centre. L'image est agrandie pour
+// contenir toute l'image tournée, et les coins ajoutés prennent la valeur
+// background. Les multiples de 90 degrés sont exacts, sans interpolation ; un
+// angle infini ou NaN laisse l'image inchangée
+func (pgm *PGM) RotateAngle(degrees float64, background uint16, interpolation Interpolation) {
+	if t, ok := quarterTurns(degrees); ok {
+		if t.src != nil {
+			pgm.turn(t)
+		}
+		return
+	}
+	width, height := pgm.Size()
+	r := newRotation(width, height, degrees)
+	sample := func(x, y int) float64 {
+		if x < 0 || y < 0 || x >= width || y >= height {
+			return float64(background)
+		}
+		return float64(pgm.row(y)[x])
+	}
+	pix := make([]uint16, r.outWidth*r.outHeight)
+	for y := 0; y < r.outHeight; y++ {
+		for x := 0; x < r.outWidth; x++ {
+			u, v := r.source(x, y)
+			pix[y*r.outWidth+x] = interpolate(interpolation, u, v, pgm.max, sample)
+		}
+	}
+	pgm.Pix, pgm.Stride, pgm.Rect = pix, r.outWidth, image.Rect(0, 0, r.outWidth, r.outHeight)
+}
+
+// Méthode pour remplacer l'image PGM par sa transformée par t ; l'image
+// obtenue commence en (0, 0)
+func (pgm *PGM) turn(t quarterTurn) {
+	width, height := pgm.Size()
+	w, h := t.size(width, height)
+	pix := make([]uint16, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sx, sy := t.src(x, y, width, height)
+			pix[y*w+x] = pgm.row(sy)[sx]
+		}
+	}
+	pgm.Pix, pgm.Stride, pgm.Rect = pix, w, image.Rect(0, 0, w, h)
+}
+
+// Méthode pour convertir une image PGM en une image PBM P1, où les pixels plus
+// sombres que la moitié de la valeur maximale deviennent noirs ; les
+// commentaires de l'en-tête sont conservés
+func (pgm *PGM) ToPBM() *PBM {
+	width, height := pgm.Size()
+	pbm := NewPBM(width, height, "P1")
+	pbm.comments = pgm.Comments()
+	for i := 0; i < height; i++ {
+		for j, v := range pgm.row(i) {
+			if 2*int(v) < pgm.max {
+				pbm.Set(j, i, true)
+			}
+		}
+	}
+	return pbm
+}
@@ -0,0 +1,232 @@
+package netpbm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"Netbpm/internal/pnm"
+)
+
+// Définition d'un lecteur PGM ligne par ligne, qui ne garde en mémoire qu'une
+// seule ligne de l'image
+type PGMRowReader struct {
+	hr            *pnm.Reader
+	magicNumber   string
+	comments      []string // Commentaires de l'en-tête
+	width, height int
+	max           int
+	y             int      // Indice de la prochaine ligne à lire
+	row           []uint16 // Ligne renvoyée par ReadRow, réutilisée d'un appel à l'autre
+	raw           []byte   // Octets bruts d'une ligne P5
+	truncated     bool     // Fin des données atteinte en mode Lenient, les lignes suivantes valent 0
+}
+
+// Fonction pour créer un lecteur ligne par ligne, après lecture de l'en-tête PGM
+func NewPGMRowReader(r io.Reader) (*PGMRowReader, error) {
+	return NewPGMRowReaderOptions(r, nil)
+}
+
+// Fonction pour créer un lecteur ligne par ligne avec les options de décodage opts, qui peuvent être nil
+func NewPGMRowReaderOptions(r io.Reader, opts *DecodeOptions) (*PGMRowReader, error) {
+	return newPGMRowReader(pnm.NewReaderOptions(r, opts), true)
+}
+
+// Fonction pour créer un lecteur à partir de hr ; rows indique une lecture ligne par ligne, où seule la largeur est limitée par défaut
+func newPGMRowReader(hr *pnm.Reader, rows bool) (*PGMRowReader, error) {
+	magicNumber, width, height, maxVal, err := readPGMHeader(hr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vérification des limites avant toute allocation : un ou deux octets par
+	// pixel en P5, au moins un caractère en P2
+	bits := 8
+	if magicNumber == "P5" {
+		bits = 8 * pnm.SampleSize(maxVal)
+	}
+	check := hr.CheckSize
+	if rows {
+		check = hr.CheckRowSize
+	}
+	if err := check("pgm", width, height, 1, bits); err != nil {
+		return nil, err
+	}
+	return &PGMRowReader{
+		hr:          hr,
+		magicNumber: magicNumber,
+		comments:    hr.Comments(),
+		width:       width,
+		height:      height,
+		max:         maxVal,
+		row:         make([]uint16, width),
+	}, nil
+}
+
+// Méthode pour obtenir la taille de l'image
+func (rr *PGMRowReader) Size() (int, int) {
+	return rr.width, rr.height
+}
+
+// Méthode pour obtenir les commentaires de l'en-tête, dans l'ordre du fichier
+func (rr *PGMRowReader) Comments() []string {
+	return rr.comments
+}
+
+// Méthode pour obtenir le numéro magique de l'image
+func (rr *PGMRowReader) Format() string {
+	return rr.magicNumber
+}
+
+// Méthode pour obtenir la valeur maximale autorisée pour un pixel
+func (rr *PGMRowReader) MaxValue() int {
+	return rr.max
+}
+
+// Méthode pour lire la ligne suivante. La tranche renvoyée est réutilisée par
+// l'appel suivant ; io.EOF est renvoyé une fois toutes les lignes lues.
+func (rr *PGMRowReader) ReadRow() ([]uint16, error) {
+	if rr.y >= rr.height {
+		return nil, io.EOF
+	}
+	i := rr.y
+
+	// Après la fin des données en mode Lenient, les lignes manquantes valent 0
+	clear(rr.row)
+	if rr.truncated {
+		rr.y++
+		return rr.row, nil
+	}
+
+	if rr.magicNumber == "P5" {
+		// Un ou deux octets par échantillon, les lignes se suivent sans séparateur
+		if rr.raw == nil {
+			rr.raw = make([]byte, rr.width*pnm.SampleSize(rr.max))
+		}
+		truncated, err := rr.hr.ReadRaster("pgm", rr.raw, i)
+		if err != nil {
+			return nil, err
+		}
+		rr.truncated = truncated
+		pnm.DecodeSamples(rr.row, rr.raw, rr.max)
+		if err := rr.hr.CheckSamples("pgm", rr.row, i, 1, rr.max); err != nil {
+			return nil, err
+		}
+	} else {
+		for j := range rr.row {
+			val, err := rr.hr.Sample("pgm", "", j, i, rr.max)
+			if rr.hr.Recover(err) {
+				rr.truncated = true
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			rr.row[j] = val
+		}
+	}
+
+	rr.y++
+	return rr.row, nil
+}
+
+// Définition d'un écrivain PGM ligne par ligne
+type PGMRowWriter struct {
+	writer        *bufio.Writer
+	magicNumber   string
+	width, height int
+	max           int
+	y             int    // Nombre de lignes déjà écrites
+	raw           []byte // Octets bruts d'une ligne P5
+}
+
+// Fonction pour créer un écrivain ligne par ligne et écrire l'en-tête PGM dans w
+func NewPGMRowWriter(w io.Writer, width, height, maxValue int, magicNumber string) (*PGMRowWriter, error) {
+	return NewPGMRowWriterComments(w, width, height, maxValue, magicNumber, nil)
+}
+
+// Fonction pour créer un écrivain ligne par ligne et écrire l'en-tête PGM dans w,
+// les commentaires comments étant écrits juste après le numéro magique
+func NewPGMRowWriterComments(w io.Writer, width, height, maxValue int, magicNumber string, comments []string) (*PGMRowWriter, error) {
+	if magicNumber != "P2" && magicNumber != "P5" {
+		return nil, fmt.Errorf("%w: PGM magic number %q", pnm.ErrUnsupported, magicNumber)
+	}
+	if maxValue < 1 || maxValue > 65535 {
+		return nil, fmt.Errorf("%w: PGM maxval %d, must be between 1 and 65535", pnm.ErrUnsupported, maxValue)
+	}
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "%s\n", magicNumber)
+	if err := pnm.WriteComments(writer, comments); err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(writer, "%d %d\n%d\n", width, height, maxValue); err != nil {
+		return nil, err
+	}
+	return &PGMRowWriter{
+		writer:      writer,
+		magicNumber: magicNumber,
+		width:       width,
+		height:      height,
+		max:         maxValue,
+	}, nil
+}
+
+// Méthode pour écrire la ligne suivante, qui doit contenir width pixels ne
+// dépassant pas la valeur maximale (sinon ErrSampleOutOfRange)
+func (rw *PGMRowWriter) WriteRow(row []uint16) error {
+	if rw.y >= rw.height {
+		return errors.New("netpbm: pgm: all rows already written")
+	}
+	if len(row) != rw.width {
+		return fmt.Errorf("netpbm: pgm: row %d has %d pixels, want %d", rw.y, len(row), rw.width)
+	}
+	if err := pnm.CheckRange("pgm", row, rw.y, 1, rw.max); err != nil {
+		return err
+	}
+
+	if rw.magicNumber == "P5" {
+		if rw.raw == nil {
+			rw.raw = make([]byte, rw.width*pnm.SampleSize(rw.max))
+		}
+		pnm.EncodeSamples(rw.raw, row, rw.max)
+		if _, err := rw.writer.Write(rw.raw); err != nil {
+			return err
+		}
+	} else {
+		for _, val := range row {
+			fmt.Fprintf(rw.writer, "%d ", val)
+		}
+		if _, err := fmt.Fprintln(rw.writer); err != nil {
+			return err
+		}
+	}
+
+	rw.y++
+	return nil
+}
+
+// Méthode pour terminer l'écriture : vide le tampon et vérifie que toutes les lignes ont été écrites
+func (rw *PGMRowWriter) Close() error {
+	if err := rw.writer.Flush(); err != nil {
+		return err
+	}
+	if rw.y != rw.height {
+		return fmt.Errorf("netpbm: pgm: only %d of %d rows written", rw.y, rw.height)
+	}
+	return nil
+}
+
+// Fonction pour inverser les couleurs d'une ligne de pixels
+func InvertPGMRow(row []uint16, maxValue int) {
+	for j := range row {
+		row[j] = uint16(maxValue) - row[j]
+	}
+}
+
+// Fonction pour inverser l'ordre des pixels d'une ligne
+func FlipPGMRow(row []uint16) {
+	for j := 0; j < len(row)/2; j++ {
+		row[j], row[len(row)-j-1] = row[len(row)-j-1], row[j]
+	}
+}
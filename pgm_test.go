@@ -0,0 +1,373 @@
+package netpbm
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"Netbpm/internal/pnm"
+)
+
+func TestReadPGMP2(t *testing.T) {
+	pgm, err := ReadPGM("duck.pgm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm.Rect.Dx() != 15 || pgm.Rect.Dy() != 15 || pgm.max != 10 {
+		t.Errorf("Wrong header: %dx%d max %d", pgm.Rect.Dx(), pgm.Rect.Dy(), pgm.max)
+	}
+	if pgm.GrayAt(0, 0) != 10 || pgm.GrayAt(7, 0) != 0 || pgm.GrayAt(10, 3) != 8 {
+		t.Error("Wrong data")
+	}
+}
+
+func TestReadPGMP5(t *testing.T) {
+	filename := writeTestFile(t, "p5.pgm", "P5\n3 2\n255\n\x00\x0a\xff\x20\x0d\x80")
+	pgm, err := ReadPGM(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint16{0x00, 0x0a, 0xff, 0x20, 0x0d, 0x80}
+	for i, v := range want {
+		if pgm.GrayAt(i%3, i/3) != v {
+			t.Errorf("Wrong data at (%d, %d): got %d, want %d", i%3, i/3, pgm.GrayAt(i%3, i/3), v)
+		}
+	}
+
+	// Relecture après sauvegarde
+	saved := filepath.Join(t.TempDir(), "save.pgm")
+	if err := pgm.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "P5\n3 2\n255\n\x00\x0a\xff\x20\x0d\x80" {
+		t.Errorf("Wrong P5 output: %q", content)
+	}
+}
+
+func TestReadPGMP5Errors(t *testing.T) {
+	_, err := ReadPGM(writeTestFile(t, "short.pgm", "P5 3 2 255\n\x00\x0a\xff\x20"))
+	var perr *pnm.ParseError
+	if !errors.Is(err, pnm.ErrTruncated) || !errors.As(err, &perr) || perr.Y != 1 || perr.Line != 2 {
+		t.Errorf("Expected a truncated raster error, got %v", err)
+	}
+	_, err = ReadPGM(writeTestFile(t, "big.pgm", "P5 2 1 100\n\x10\xc8"))
+	if !errors.Is(err, pnm.ErrSampleOutOfRange) || !errors.As(err, &perr) || perr.X != 1 || perr.Offset != 12 {
+		t.Errorf("Expected an out of range error, got %v", err)
+	}
+	_, err = ReadPGM(writeTestFile(t, "magic.pgm", "P6 2 1 100\n\x10\xc8"))
+	if !errors.Is(err, pnm.ErrBadMagic) || !errors.As(err, &perr) || perr.Format != "pgm" || perr.X != -1 {
+		t.Errorf("Expected a bad magic number error, got %v", err)
+	}
+}
+
+func TestReadPGM16Bit(t *testing.T) {
+	content := "P5\n2 1\n4095\n\x0f\xff\x01\x00"
+	pgm, err := ReadPGM(writeTestFile(t, "p5.pgm", content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm.GrayAt(0, 0) != 4095 || pgm.GrayAt(1, 0) != 256 {
+		t.Errorf("Wrong data: %d %d", pgm.GrayAt(0, 0), pgm.GrayAt(1, 0))
+	}
+	pgm.Invert()
+	if pgm.GrayAt(0, 0) != 0 || pgm.GrayAt(1, 0) != 3839 {
+		t.Errorf("Wrong inverted data: %d %d", pgm.GrayAt(0, 0), pgm.GrayAt(1, 0))
+	}
+
+	pgm.SetMagicNumber("P2")
+	saved := filepath.Join(t.TempDir(), "save.pgm")
+	if err := pgm.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	pgm2, err := ReadPGM(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm2.max != 4095 || pgm2.GrayAt(1, 0) != 3839 {
+		t.Errorf("Wrong data after P2 round trip: max %d, %d", pgm2.max, pgm2.GrayAt(1, 0))
+	}
+}
+
+func TestDecodeEncodePGM(t *testing.T) {
+	content := "P2\n3 1\n9\n0 4 9 \n"
+	pgm, err := DecodePGM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := pgm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != content {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPGMMaxValueRange(t *testing.T) {
+	// Une valeur maximale hors de 1..65535 est ramenée dans la plage
+	if c := NewPGM(1, 1, 0, "P5").At(0, 0); c != (color.Gray16{}) {
+		t.Errorf("Wrong color: %v", c)
+	}
+	src := image.NewGray(image.Rect(0, 0, 1, 1))
+	src.Pix[0] = 0xff
+	pgm := PGMFromImage(src, 70000, "P5")
+	if pgm.MaxValue() != 65535 || pgm.GrayAt(0, 0) != 65535 {
+		t.Errorf("Wrong maxval %d or sample %d", pgm.MaxValue(), pgm.GrayAt(0, 0))
+	}
+	var buf bytes.Buffer
+	if err := pgm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodePGM(&buf); err != nil {
+		t.Errorf("Encoded image cannot be decoded: %v", err)
+	}
+
+	// Les écrivains refusent une valeur maximale hors de la plage
+	for _, maxValue := range []int{0, -1, 65536} {
+		if _, err := NewPGMRowWriter(io.Discard, 1, 1, maxValue, "P5"); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("maxval %d: expected ErrUnsupported, got %v", maxValue, err)
+		}
+	}
+}
+
+func TestRowReaderWriterPGM(t *testing.T) {
+	// Inversion d'une image ligne par ligne, comparée à Invert puis Flip
+	content := "P5\n3 2\n255\n\x00\x0a\xff\x20\x0d\x80"
+	rr, err := NewPGMRowReader(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	width, height := rr.Size()
+	var buf bytes.Buffer
+	rw, err := NewPGMRowWriter(&buf, width, height, rr.MaxValue(), rr.Format())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		row, err := rr.ReadRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		InvertPGMRow(row, rr.MaxValue())
+		FlipPGMRow(row)
+		if err := rw.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	pgm, err := DecodePGM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pgm.Invert()
+	pgm.Flip()
+	var want bytes.Buffer
+	if err := pgm.Encode(&want); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want.String() {
+		t.Errorf("Wrong output: %q, want %q", buf.String(), want.String())
+	}
+}
+
+func TestRowWriterMissingRows(t *testing.T) {
+	rw, err := NewPGMRowWriter(io.Discard, 2, 2, 255, "P2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rw.WriteRow([]uint16{1}); err == nil {
+		t.Error("Expected an error for a short row")
+	}
+	if err := rw.WriteRow([]uint16{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rw.Close(); err == nil {
+		t.Error("Expected an error for a missing row")
+	}
+}
+
+func TestPGMSubImage(t *testing.T) {
+	pgm := NewPGM(4, 3, 255, "P5")
+	for i := range pgm.Pix {
+		pgm.Pix[i] = uint16(i)
+	}
+	sub := pgm.SubImage(image.Rect(1, 1, 3, 3)).(*PGM)
+	if w, h := sub.Size(); w != 2 || h != 2 || sub.GrayAt(1, 1) != 5 {
+		t.Fatalf("Wrong sub-image: %dx%d, %d", w, h, sub.GrayAt(1, 1))
+	}
+	sub.Flip()
+	if pgm.GrayAt(1, 1) != 6 || pgm.GrayAt(2, 1) != 5 || pgm.GrayAt(0, 1) != 4 {
+		t.Error("Sub-image does not share pixels with its parent")
+	}
+
+	var buf bytes.Buffer
+	if err := sub.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P5\n2 2\n255\n\x06\x05\x0a\x09" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPGMToPBMThreshold(t *testing.T) {
+	tests := []struct {
+		content, want string
+	}{
+		{"P2 4 1 10 0 4 6 10\n", "P1\n4 1\n1 1 0 0 \n"},
+		// Avec une valeur maximale de 1, 0 est noir et 1 est blanc
+		{"P2 2 1 1 0 1\n", "P1\n2 1\n1 0 \n"},
+		{"P2 3 1 3 1 2 3\n", "P1\n3 1\n1 0 0 \n"},
+	}
+	for _, test := range tests {
+		pgm, err := DecodePGM(strings.NewReader(test.content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := pgm.ToPBM().Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("%q: dark pixels must become black: %q", test.content, buf.String())
+		}
+	}
+}
+
+func TestPGMComments(t *testing.T) {
+	content := "P2\n# CREATOR: scanner-07 exposure=1/60\n# étalonné\n2 1\n15\n0 15\n"
+	pgm, err := DecodePGM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments := pgm.Comments()
+	if len(comments) != 2 || comments[0] != "CREATOR: scanner-07 exposure=1/60" || comments[1] != "étalonné" {
+		t.Fatalf("Wrong comments: %q", comments)
+	}
+	if exposure, ok := pgm.Metadata("exposure"); !ok || exposure != "1/60" {
+		t.Errorf("Wrong exposure: %q, %v", exposure, ok)
+	}
+
+	var buf bytes.Buffer
+	if err := pgm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P2\n# CREATOR: scanner-07 exposure=1/60\n# étalonné\n2 1\n15\n0 15 \n" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+
+	// Une métadonnée existante est remplacée, une nouvelle est ajoutée à la fin
+	pgm.SetMetadata("gamma", "2.2")
+	pgm.SetMetadata("gamma", "1.8")
+	pgm.SetComments(append(pgm.Comments()[:1], pgm.Comments()[2]))
+	buf.Reset()
+	if err := pgm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodePGM(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gamma, ok := decoded.Metadata("gamma"); !ok || gamma != "1.8" || len(decoded.Comments()) != 2 {
+		t.Errorf("Wrong comments after round trip: %q", decoded.Comments())
+	}
+}
+
+func TestPGMRotations(t *testing.T) {
+	tests := []struct {
+		name   string
+		rotate func(pgm *PGM)
+		want   string
+	}{
+		{"Rotate90CW", (*PGM).Rotate90CW, "P2\n2 3\n5\n3 0 \n4 1 \n5 2 \n"},
+		{"Rotate90CCW", (*PGM).Rotate90CCW, "P2\n2 3\n5\n2 5 \n1 4 \n0 3 \n"},
+		{"Rotate180", (*PGM).Rotate180, "P2\n3 2\n5\n5 4 3 \n2 1 0 \n"},
+		{"Transpose", (*PGM).Transpose, "P2\n2 3\n5\n0 3 \n1 4 \n2 5 \n"},
+		{"Transverse", (*PGM).Transverse, "P2\n2 3\n5\n5 2 \n4 1 \n3 0 \n"},
+		{"RotateAngle(-270)", func(pgm *PGM) { pgm.RotateAngle(-270, 0, Bicubic) }, "P2\n2 3\n5\n3 0 \n4 1 \n5 2 \n"},
+	}
+	for _, test := range tests {
+		pgm, err := DecodePGM(strings.NewReader("P2 3 2 5 0 1 2 3 4 5\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.rotate(pgm)
+		var buf bytes.Buffer
+		if err := pgm.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, buf.String(), test.want)
+		}
+	}
+}
+
+func TestPGMRotateAngle(t *testing.T) {
+	for _, interpolation := range []Interpolation{Nearest, Bilinear, Bicubic} {
+		pgm := NewPGM(8, 8, 255, "P5")
+		for i := range pgm.Pix {
+			pgm.Pix[i] = 200
+		}
+		pgm.RotateAngle(45, 7, interpolation)
+
+		// La diagonale de l'image, 8√2, devient sa largeur
+		if w, h := pgm.Size(); w != 12 || h != 12 {
+			t.Fatalf("%d: wrong size %dx%d", interpolation, w, h)
+		}
+		if pgm.GrayAt(0, 0) != 7 || pgm.GrayAt(11, 11) != 7 {
+			t.Errorf("%d: corners must take the background value: %d, %d", interpolation, pgm.GrayAt(0, 0), pgm.GrayAt(11, 11))
+		}
+		if pgm.GrayAt(6, 6) != 200 || pgm.GrayAt(6, 4) != 200 {
+			t.Errorf("%d: wrong inside values: %d, %d", interpolation, pgm.GrayAt(6, 6), pgm.GrayAt(6, 4))
+		}
+	}
+
+	// Un angle proche d'un quart de tour n'ajoute pas de colonne
+	pgm := NewPGM(4, 2, 255, "P5")
+	pgm.RotateAngle(90.0000001, 0, Bilinear)
+	if w, h := pgm.Size(); w != 2 || h != 4 {
+		t.Errorf("Wrong size: %dx%d", w, h)
+	}
+	// Un angle infini ou NaN laisse l'image inchangée
+	for _, degrees := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		pgm.RotateAngle(degrees, 0, Bilinear)
+		if w, h := pgm.Size(); w != 2 || h != 4 {
+			t.Errorf("RotateAngle(%v): wrong size %dx%d", degrees, w, h)
+		}
+	}
+}
+
+func TestPGMEncodeSampleOutOfRange(t *testing.T) {
+	for _, magicNumber := range []string{"P5", "P2"} {
+		pgm := NewPGM(2, 1, 255, magicNumber)
+		pgm.Set(0, 0, 300)
+		var buf bytes.Buffer
+		if err := pgm.Encode(&buf); !errors.Is(err, ErrSampleOutOfRange) {
+			t.Errorf("%s: got error %v, want ErrSampleOutOfRange", magicNumber, err)
+		}
+
+		// Une valeur maximale abaissée après coup laisse des pixels hors plage
+		pgm = NewPGM(2, 1, 255, magicNumber)
+		pgm.Set(1, 0, 200)
+		pgm.SetMaxValue(100)
+		if err := pgm.Encode(&buf); !errors.Is(err, ErrSampleOutOfRange) {
+			t.Errorf("%s after SetMaxValue: got error %v, want ErrSampleOutOfRange", magicNumber, err)
+		}
+	}
+}
@@ -0,0 +1,563 @@
+package netpbm
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Op est une opération d'un Pipeline. Elle modifie img en place ou renvoie une
+// autre image, éventuellement d'un autre type.
+type Op func(img Image) (Image, error)
+
+// Pipeline est une suite d'opérations appliquées dans l'ordre à une même image
+// en mémoire, sans passer par un fichier entre deux opérations. Lorsqu'une
+// opération n'existe pas pour le type de l'image, celle-ci est d'abord
+// convertie : une image PAM devient PBM, PGM ou PPM pour Invert, Flip, Flop et
+// Rotate, et toute image devient PPM pour Draw.
+type Pipeline []Op
+
+// Apply applique les opérations de p à img et renvoie l'image obtenue.
+func (p Pipeline) Apply(img Image) (Image, error) {
+	for _, op := range p {
+		var err error
+		if img, err = op(img); err != nil {
+			return nil, err
+		}
+	}
+	return img, nil
+}
+
+// commented est implémentée par les images qui conservent les commentaires
+// de leur en-tête.
+type commented interface {
+	Comments() []string
+	SetComments(comments []string)
+}
+
+// copyComments recopie les commentaires de src dans dst, lorsque les deux
+// images les conservent.
+func copyComments(dst, src Image) {
+	from, ok1 := src.(commented)
+	to, ok2 := dst.(commented)
+	if ok1 && ok2 {
+		to.SetComments(from.Comments())
+	}
+}
+
+// isASCII indique si le numéro magique désigne un format texte (P1 à P3).
+func isASCII(magicNumber string) bool {
+	return magicNumber == "P1" || magicNumber == "P2" || magicNumber == "P3"
+}
+
+// binaryFormat renvoie le numéro magique du format binaire équivalent : P4
+// pour P1, P5 pour P2 et P6 pour P3.
+func binaryFormat(magicNumber string) string {
+	switch magicNumber {
+	case "P1":
+		return "P4"
+	case "P2":
+		return "P5"
+	case "P3":
+		return "P6"
+	}
+	return magicNumber
+}
+
+// sameEncoding renvoie le numéro magique binary, ou son équivalent texte si
+// img est au format texte.
+func sameEncoding(img Image, binary string) string {
+	if !isASCII(img.Format()) {
+		return binary
+	}
+	switch binary {
+	case "P4":
+		return "P1"
+	case "P5":
+		return "P2"
+	case "P6":
+		return "P3"
+	}
+	return binary
+}
+
+// defaultMaxValue renvoie maxValue si elle est positive, sinon la valeur
+// maximale de img, ou 255 pour une image PBM.
+func defaultMaxValue(img Image, maxValue int) int {
+	if maxValue > 0 {
+		return maxValue
+	}
+	if img.MaxValue() == 1 {
+		return 255
+	}
+	return img.MaxValue()
+}
+
+// convert convertit img dans le format magicNumber avec la valeur maximale
+// maxValue, en gardant ses commentaires. Une image déjà dans la bonne famille
+// et à la bonne valeur maximale change seulement de numéro magique.
+func convert(img Image, magicNumber string, maxValue int) (Image, error) {
+	if magicNumber == "P7" {
+		return toPAM(img)
+	}
+	target := binaryFormat(magicNumber)
+	if target == binaryFormat(img.Format()) && (maxValue == img.MaxValue() || target == "P4") {
+		if m, ok := img.(interface{ SetMagicNumber(string) }); ok {
+			m.SetMagicNumber(magicNumber)
+			return img, nil
+		}
+	}
+	converted, err := FromImage(img, magicNumber, maxValue)
+	if err != nil {
+		return nil, err
+	}
+	copyComments(converted, img)
+	return converted, nil
+}
+
+// toPAM convertit img en image PAM.
+func toPAM(img Image) (Image, error) {
+	switch img := img.(type) {
+	case *PAM:
+		return img, nil
+	case *PBM:
+		return PAMFromPBM(img), nil
+	case *PGM:
+		return PAMFromPGM(img), nil
+	case *PPM:
+		return PAMFromPPM(img), nil
+	}
+	return nil, fmt.Errorf("%w: image type %T", ErrUnsupported, img)
+}
+
+// editable convertit une image PAM, qui n'a ni Invert, ni Flip, ni Flop, en
+// image PBM, PGM ou PPM selon son type de tuple ou sa profondeur. Une image
+// PAM avec opacité, ou d'une profondeur autre que 1 ou 3, perdrait des
+// échantillons : elle est refusée avec ErrUnsupported. Les autres images sont
+// renvoyées telles quelles.
+func editable(img Image) (Image, error) {
+	pam, ok := img.(*PAM)
+	if !ok {
+		return img, nil
+	}
+	if converted := fromPAM(pam); converted != img {
+		return converted, nil
+	}
+	switch {
+	case pam.hasAlpha() || (pam.Depth() != 1 && pam.Depth() != 3):
+		return nil, fmt.Errorf("%w: PAM tuple type %q with depth %d", ErrUnsupported, pam.TupleType(), pam.Depth())
+	case pam.Depth() == 3:
+		return pam.ToPPM(), nil
+	}
+	return pam.ToPGM(), nil
+}
+
+// Invert renvoie l'opération qui inverse les couleurs de l'image.
+func Invert() Op {
+	return func(img Image) (Image, error) {
+		img, err := editable(img)
+		if err != nil {
+			return nil, err
+		}
+		img.(interface{ Invert() }).Invert()
+		return img, nil
+	}
+}
+
+// Flip renvoie l'opération qui inverse l'image de gauche à droite.
+func Flip() Op {
+	return func(img Image) (Image, error) {
+		img, err := editable(img)
+		if err != nil {
+			return nil, err
+		}
+		img.(interface{ Flip() }).Flip()
+		return img, nil
+	}
+}
+
+// Flop renvoie l'opération qui inverse l'image de haut en bas.
+func Flop() Op {
+	return func(img Image) (Image, error) {
+		img, err := editable(img)
+		if err != nil {
+			return nil, err
+		}
+		img.(interface{ Flop() }).Flop()
+		return img, nil
+	}
+}
+
+// Rotate renvoie l'opération qui fait pivoter l'image de degrees degrés dans
+// le sens des aiguilles d'une montre, éventuellement négatif. Les multiples de
+// 90 sont exacts ; pour les autres angles, voir RotateAngle, avec un fond noir
+// et l'interpolation Bilinear.
+func Rotate(degrees int) Op {
+	return RotateAngle(float64(degrees), Pixel{}, Bilinear)
+}
+
+// RotateAngle renvoie l'opération qui fait pivoter l'image de degrees degrés
+// dans le sens des aiguilles d'une montre autour de son centre, avec la
+// méthode RotateAngle de son type. L'image est agrandie pour contenir toute
+// l'image tournée, et les coins ajoutés prennent la couleur background,
+// exprimée dans la plage de valeurs de l'image : une image PGM prend la
+// moyenne de ses trois composantes, une image PBM est noire lorsque cette
+// moyenne est nulle. Une image PBM est toujours tournée avec Nearest.
+func RotateAngle(degrees float64, background Pixel, interpolation Interpolation) Op {
+	return func(img Image) (Image, error) {
+		if t, ok := quarterTurns(degrees); ok && t.src == nil {
+			return img, nil
+		}
+		gray := uint16((uint32(background.R) + uint32(background.G) + uint32(background.B)) / 3)
+
+		img, err := editable(img)
+		if err != nil {
+			return nil, err
+		}
+		switch img := img.(type) {
+		case *PBM:
+			img.RotateAngle(degrees, gray == 0)
+		case *PGM:
+			img.RotateAngle(degrees, gray, interpolation)
+		case *PPM:
+			img.RotateAngle(degrees, background, interpolation)
+		default:
+			return nil, fmt.Errorf("%w: rotation of %T", ErrUnsupported, img)
+		}
+		return img, nil
+	}
+}
+
+// Crop renvoie l'opération qui ne garde que la partie r de l'image, r étant
+// exprimé par rapport au coin supérieur gauche de l'image.
+func Crop(r image.Rectangle) Op {
+	return func(img Image) (Image, error) {
+		img, err := editable(img)
+		if err != nil {
+			return nil, err
+		}
+		sub := img.(interface {
+			SubImage(r image.Rectangle) image.Image
+		}).SubImage(r.Add(img.Bounds().Min))
+		if sub.Bounds().Empty() {
+			return nil, fmt.Errorf("crop: rectangle %v outside the image", r)
+		}
+		return sub.(Image), nil
+	}
+}
+
+// Convert renvoie l'opération qui convertit l'image dans le format
+// magicNumber, de P1 à P7, avec la valeur maximale maxValue ; 0 garde la
+// valeur maximale de l'image (255 pour une image PBM).
+func Convert(magicNumber string, maxValue int) Op {
+	return func(img Image) (Image, error) {
+		return convert(img, magicNumber, defaultMaxValue(img, maxValue))
+	}
+}
+
+// ToPBM renvoie l'opération qui convertit l'image en image PBM, avec la
+// méthode ToPBM de son type lorsqu'elle existe. L'image obtenue est au format
+// texte P1 si l'image d'origine l'était, au format binaire P4 sinon.
+func ToPBM() Op {
+	return func(img Image) (Image, error) {
+		var pbm *PBM
+		switch img := img.(type) {
+		case *PBM:
+			return img, nil
+		case *PGM:
+			pbm = img.ToPBM()
+		case *PPM:
+			pbm = img.ToPBM()
+		case *PAM:
+			pbm = img.ToPBM()
+		default:
+			return convert(img, sameEncoding(img, "P4"), 1)
+		}
+		pbm.SetMagicNumber(sameEncoding(img, "P4"))
+		return pbm, nil
+	}
+}
+
+// ToPGM renvoie l'opération qui convertit l'image en image PGM, avec la
+// méthode ToPGM de son type lorsqu'elle existe. maxValue ne sert qu'aux images
+// PBM ; 0 vaut 255. Comme pour ToPBM, l'image obtenue garde le codage, texte
+// ou binaire, de l'image d'origine.
+func ToPGM(maxValue int) Op {
+	return func(img Image) (Image, error) {
+		var pgm *PGM
+		switch img := img.(type) {
+		case *PGM:
+			return img, nil
+		case *PPM:
+			pgm = img.ToPGM()
+		case *PAM:
+			pgm = img.ToPGM()
+		default:
+			return convert(img, sameEncoding(img, "P5"), defaultMaxValue(img, maxValue))
+		}
+		pgm.SetMagicNumber(sameEncoding(img, "P5"))
+		return pgm, nil
+	}
+}
+
+// ToPPM renvoie l'opération qui convertit l'image en image PPM. maxValue ne
+// sert qu'aux images PBM ; 0 vaut 255.
+func ToPPM(maxValue int) Op {
+	return func(img Image) (Image, error) {
+		switch img := img.(type) {
+		case *PPM:
+			return img, nil
+		case *PAM:
+			return img.ToPPM(), nil
+		}
+		return convert(img, sameEncoding(img, "P6"), defaultMaxValue(img, maxValue))
+	}
+}
+
+// shapes associe à chaque figure de Draw le nombre d'entiers attendus (-1 pour
+// une liste de points x,y) et la méthode de PPM qui la trace.
+var shapes = map[string]struct {
+	n    int
+	draw func(ppm *PPM, v []int, p []Point, c Pixel)
+}{
+	"line":         {4, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawLine(p[0], p[1], c) }},
+	"rect":         {4, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawRectangle(p[0], v[2], v[3], c) }},
+	"fillrect":     {4, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawFilledRectangle(p[0], v[2], v[3], c) }},
+	"circle":       {3, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawCircle(p[0], v[2], c) }},
+	"fillcircle":   {3, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawFilledCircle(p[0], v[2], c) }},
+	"triangle":     {6, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawTriangle(p[0], p[1], p[2], c) }},
+	"filltriangle": {6, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawFilledTriangle(p[0], p[1], p[2], c) }},
+	"polygon":      {-1, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawPolygon(p, c) }},
+	"fillpolygon":  {-1, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawFilledPolygon(p, c) }},
+	"koch":         {4, func(ppm *PPM, v []int, p []Point, c Pixel) { ppm.DrawKochSnowflake(p[0], v[2], v[3], c) }},
+}
+
+// Draw renvoie l'opération qui trace la figure shape de couleur c sur
+// l'image, convertie au préalable en image PPM si besoin. Les coordonnées sont
+// exprimées par rapport au coin supérieur gauche de l'image. Les figures et
+// leurs valeurs sont :
+//
+//	line x1,y1,x2,y2            rect, fillrect x,y,largeur,hauteur
+//	circle, fillcircle x,y,r    triangle, filltriangle x1,y1,x2,y2,x3,y3
+//	polygon, fillpolygon x1,y1,x2,y2,...
+//	koch x,y,rayon,profondeur
+func Draw(shape string, values []int, c Pixel) (Op, error) {
+	s, ok := shapes[shape]
+	if !ok {
+		return nil, fmt.Errorf("%w: shape %q", ErrUnsupported, shape)
+	}
+	if s.n >= 0 && len(values) != s.n {
+		return nil, fmt.Errorf("%s: expected %d values, got %d", shape, s.n, len(values))
+	}
+	if s.n < 0 && (len(values) < 4 || len(values)%2 != 0) {
+		return nil, fmt.Errorf("%s: expected at least two x,y points", shape)
+	}
+	points := make([]Point, len(values)/2)
+	for i := range points {
+		points[i] = Point{X: values[2*i], Y: values[2*i+1]}
+	}
+
+	return func(img Image) (Image, error) {
+		ppm, ok := img.(*PPM)
+		if !ok {
+			converted, err := ToPPM(0)(img)
+			if err != nil {
+				return nil, err
+			}
+			ppm = converted.(*PPM)
+		}
+		// Les points sont exprimés, comme pour Crop, par rapport au coin
+		// supérieur gauche de l'image
+		min := ppm.Bounds().Min
+		shifted := make([]Point, len(points))
+		for i, p := range points {
+			shifted[i] = Point{X: p.X + min.X, Y: p.Y + min.Y}
+		}
+		s.draw(ppm, values, shifted, c)
+		return ppm, nil
+	}, nil
+}
+
+// ParsePipeline lit une suite d'opérations séparées par des "|", comme
+// "invert | rotate 90 | topgm". Chaque étape est un nom suivi de ses
+// arguments, séparés par des blancs :
+//
+//	invert, flip, flop
+//	rotate [degrés [r,g,b [nearest|bilinear|bicubic]]]
+//	                              90, noir et bilinear par défaut
+//	crop x,y,largeur,hauteur
+//	convert P1..P7 [maxval]
+//	topbm, topgm [maxval], toppm [maxval]
+//	draw figure valeurs [r,g,b]   voir Draw ; noir par défaut
+func ParsePipeline(s string) (Pipeline, error) {
+	var p Pipeline
+	for i, stage := range strings.Split(s, "|") {
+		fields := strings.Fields(stage)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("pipeline: empty step %d", i+1)
+		}
+		op, err := parseStep(fields[0], fields[1:])
+		if err != nil {
+			return nil, fmt.Errorf("pipeline: step %d: %w", i+1, err)
+		}
+		p = append(p, op)
+	}
+	return p, nil
+}
+
+// parseStep construit l'opération name à partir de ses arguments.
+func parseStep(name string, args []string) (Op, error) {
+	// Nombre d'arguments accepté par chaque étape
+	counts := map[string][2]int{
+		"invert": {0, 0}, "flip": {0, 0}, "flop": {0, 0},
+		"rotate": {0, 3}, "crop": {1, 1}, "convert": {1, 2},
+		"topbm": {0, 0}, "topgm": {0, 1}, "toppm": {0, 1},
+		"draw": {2, 3},
+	}
+	count, ok := counts[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", name)
+	}
+	if len(args) < count[0] || len(args) > count[1] {
+		return nil, fmt.Errorf("%s: wrong number of arguments", name)
+	}
+
+	// Argument entier facultatif, 0 s'il est absent
+	intArg := func(i int) (int, error) {
+		if i >= len(args) {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(args[i])
+		if err != nil {
+			return 0, fmt.Errorf("%s: invalid number %q", name, args[i])
+		}
+		return n, nil
+	}
+
+	switch name {
+	case "invert":
+		return Invert(), nil
+	case "flip":
+		return Flip(), nil
+	case "flop":
+		return Flop(), nil
+	case "rotate":
+		degrees := 90.0
+		if len(args) >= 1 {
+			var err error
+			degrees, err = strconv.ParseFloat(args[0], 64)
+			if err != nil || math.IsNaN(degrees) || math.IsInf(degrees, 0) {
+				return nil, fmt.Errorf("rotate: invalid angle %q", args[0])
+			}
+		}
+		background := Pixel{}
+		if len(args) >= 2 {
+			var err error
+			if background, err = ParseColor(args[1]); err != nil {
+				return nil, fmt.Errorf("rotate: %w", err)
+			}
+		}
+		interpolation := Bilinear
+		if len(args) == 3 {
+			var err error
+			if interpolation, err = ParseInterpolation(args[2]); err != nil {
+				return nil, fmt.Errorf("rotate: %w", err)
+			}
+		}
+		return RotateAngle(degrees, background, interpolation), nil
+	case "crop":
+		v, err := ParseInts(args[0])
+		if err != nil || len(v) != 4 {
+			return nil, fmt.Errorf("crop: expected x,y,width,height, got %q", args[0])
+		}
+		return Crop(image.Rect(v[0], v[1], v[0]+v[2], v[1]+v[3])), nil
+	case "convert":
+		magicNumber := strings.ToUpper(args[0])
+		if len(magicNumber) != 2 || magicNumber[0] != 'P' || magicNumber[1] < '1' || magicNumber[1] > '7' {
+			return nil, fmt.Errorf("%w: magic number %q", ErrUnsupported, args[0])
+		}
+		maxValue, err := intArg(1)
+		if err != nil {
+			return nil, err
+		}
+		return Convert(magicNumber, maxValue), nil
+	case "topbm":
+		return ToPBM(), nil
+	case "topgm", "toppm":
+		maxValue, err := intArg(0)
+		if err != nil {
+			return nil, err
+		}
+		if name == "topgm" {
+			return ToPGM(maxValue), nil
+		}
+		return ToPPM(maxValue), nil
+	}
+
+	// draw figure valeurs [couleur]
+	values, err := ParseInts(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("draw: %w", err)
+	}
+	c := Pixel{}
+	if len(args) == 3 {
+		if c, err = ParseColor(args[2]); err != nil {
+			return nil, fmt.Errorf("draw: %w", err)
+		}
+	}
+	return Draw(args[0], values, c)
+}
+
+// ParseInts lit une liste d'entiers séparés par des virgules, comme "0,0,10,5".
+func ParseInts(s string) ([]int, error) {
+	if s == "" {
+		return nil, errors.New("missing value")
+	}
+	fields := strings.Split(s, ",")
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		v, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("%q: invalid integer %q", s, field)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// ParseColor lit une couleur "r,g,b", ou un seul niveau de gris "v".
+func ParseColor(s string) (Pixel, error) {
+	values, err := ParseInts(s)
+	if err != nil {
+		return Pixel{}, err
+	}
+	if len(values) != 1 && len(values) != 3 {
+		return Pixel{}, fmt.Errorf("%q: expected r,g,b or a gray level", s)
+	}
+	for _, v := range values {
+		if v < 0 || v > 65535 {
+			return Pixel{}, fmt.Errorf("%q: color component out of range", s)
+		}
+	}
+	if len(values) == 1 {
+		return Pixel{R: uint16(values[0]), G: uint16(values[0]), B: uint16(values[0])}, nil
+	}
+	return Pixel{R: uint16(values[0]), G: uint16(values[1]), B: uint16(values[2])}, nil
+}
+
+// ParseInterpolation lit le nom d'une méthode d'interpolation : "nearest",
+// "bilinear" ou "bicubic".
+func ParseInterpolation(s string) (Interpolation, error) {
+	switch strings.ToLower(s) {
+	case "nearest":
+		return Nearest, nil
+	case "bilinear":
+		return Bilinear, nil
+	case "bicubic":
+		return Bicubic, nil
+	}
+	return 0, fmt.Errorf("%q: expected nearest, bilinear or bicubic", s)
+}
@@ -0,0 +1,133 @@
+package netpbm
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestParsePipeline(t *testing.T) {
+	p, err := ParsePipeline("invert | rotate 90 | topgm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 3 {
+		t.Fatalf("Wrong number of operations: %d", len(p))
+	}
+
+	img, err := Decode(strings.NewReader("P3 2 1 255 255 255 255 0 0 0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img, err = p.Apply(img); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := img.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P2\n1 2\n255\n0 \n255 \n" {
+		t.Errorf("Wrong result: %q", buf.String())
+	}
+
+	for _, s := range []string{"", "invert |", "blur", "rotate x", "rotate 45 1,2", "rotate 45 0 cubic", "crop 1,2", "convert P9", "topgm 1 2", "draw star 1,2"} {
+		if _, err := ParsePipeline(s); err == nil {
+			t.Errorf("ParsePipeline(%q): expected an error", s)
+		}
+	}
+}
+
+func TestPipelineConversions(t *testing.T) {
+	tests := []struct {
+		in     string
+		p      Pipeline
+		format string
+		want   string
+	}{
+		// Une image PAM est convertie pour être inversée
+		{"P7\nWIDTH 2\nHEIGHT 1\nDEPTH 1\nMAXVAL 255\nTUPLTYPE GRAYSCALE\nENDHDR\n\x00\x10", Pipeline{Invert()}, "P5", "P5\n2 1\n255\n\xff\xef"},
+		// Une image PBM reste une image PBM une fois tournée
+		{"P1 2 1 1 0\n", Pipeline{Rotate(-90)}, "P1", "P1\n1 2\n0 \n1 \n"},
+		// Les coins ajoutés par un angle quelconque prennent la couleur de fond
+		{"P1 2 2 0 0 0 0\n", Pipeline{RotateAngle(45, Pixel{}, Bicubic)}, "P1", "P1\n3 3\n1 0 1 \n0 0 0 \n1 0 1 \n"},
+		// Une image PGM devient une image PPM pour le tracé
+		{"P5 2 1 9\n\x00\x00", Pipeline{mustDraw(t, "line", []int{1, 0, 1, 0}, Pixel{9, 0, 0}), Flip()}, "P6", "P6\n2 1\n9\n\x09\x00\x00\x00\x00\x00"},
+		{"P2 2 1 9 0 9\n", Pipeline{Convert("P7", 0), ToPBM()}, "P4", "P4\n2 1\n\x80"},
+		// Une image binaire reste binaire une fois convertie
+		{"P5 2 1 255\n\x00\xff", Pipeline{ToPBM()}, "P4", "P4\n2 1\n\x80"},
+		{"P6 1 1 255\n\xff\xff\xff", Pipeline{ToPGM(0)}, "P5", "P5\n1 1\n255\n\xff"},
+		// Les points du tracé sont relatifs à l'image recadrée
+		{"P6 3 3 9\n" + strings.Repeat("\x00", 27), Pipeline{Crop(image.Rect(1, 1, 3, 3)), mustDraw(t, "line", []int{0, 0, 1, 1}, Pixel{9, 9, 9})}, "P6",
+			"P6\n2 2\n9\n\x09\x09\x09\x00\x00\x00\x00\x00\x00\x09\x09\x09"},
+	}
+	for _, test := range tests {
+		img, err := Decode(strings.NewReader(test.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img, err = test.p.Apply(img); err != nil {
+			t.Fatal(err)
+		}
+		if img.Format() != test.format {
+			t.Errorf("%q: wrong format %s, want %s", test.in, img.Format(), test.format)
+		}
+		var buf bytes.Buffer
+		if err := img.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("%q: got %q, want %q", test.in, buf.String(), test.want)
+		}
+	}
+}
+
+func TestPipelineAlphaPAM(t *testing.T) {
+	inputs := []string{
+		"P7\nWIDTH 2\nHEIGHT 1\nDEPTH 4\nMAXVAL 255\nTUPLTYPE RGB_ALPHA\nENDHDR\n\xff\x00\x00\x80\x00\x00\xff\xff",
+		"P7\nWIDTH 2\nHEIGHT 1\nDEPTH 2\nMAXVAL 255\nTUPLTYPE GRAYSCALE_ALPHA\nENDHDR\n\x10\x80\x20\xff",
+		"P7\nWIDTH 1\nHEIGHT 1\nDEPTH 5\nMAXVAL 255\nENDHDR\n\x01\x02\x03\x04\x05",
+	}
+	ops := map[string]Op{
+		"invert": Invert(),
+		"flip":   Flip(),
+		"flop":   Flop(),
+		"rotate": Rotate(90),
+		"crop":   Crop(image.Rect(0, 0, 1, 1)),
+	}
+	for _, in := range inputs {
+		for name, op := range ops {
+			img, err := Decode(strings.NewReader(in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// L'opacité serait perdue par la conversion en PGM ou PPM
+			if _, err := op(img); !errors.Is(err, ErrUnsupported) {
+				t.Errorf("%s on %q: got error %v, want ErrUnsupported", name, in, err)
+			}
+		}
+	}
+
+	// Sans opacité, une image PAM de profondeur 3 reste modifiable
+	img, err := Decode(strings.NewReader("P7\nWIDTH 2\nHEIGHT 1\nDEPTH 3\nMAXVAL 255\nENDHDR\n\x00\x10\x20\x30\x40\x50"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img, err = Flip()(img); err != nil {
+		t.Fatal(err)
+	}
+	if c := img.(*PPM).PixelAt(0, 0); c != (Pixel{0x30, 0x40, 0x50}) {
+		t.Errorf("Wrong pixel after flip: %v", c)
+	}
+}
+
+// mustDraw renvoie l'opération Draw, ou arrête le test en cas d'erreur.
+func mustDraw(t *testing.T, shape string, values []int, c Pixel) Op {
+	t.Helper()
+	op, err := Draw(shape, values, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return op
+}
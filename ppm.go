@@ -0,0 +1,766 @@
+package netpbm
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+
+	"Netbpm/internal/pnm"
+)
+
+type Pixel struct {
+	R, G, B uint16
+}
+
+type PPM struct {
+	Pix         []Pixel         // pixels, ligne après ligne, dans un seul tableau
+	Stride      int             // écart dans Pix entre deux pixels verticalement adjacents
+	Rect        image.Rectangle // rectangle occupé par l'image
+	magicNumber string
+	max         int
+	comments    []string // commentaires de l'en-tête, dans l'ordre du fichier
+}
+
+type Point struct {
+	X, Y int
+}
+
+// NewPPM crée une image PPM noire de la taille et de la valeur maximale données.
+// maxValue est ramenée dans la plage 1..65535 autorisée par le format.
+
+func NewPPM(width, height, maxValue int, magicNumber string) *PPM {
+	maxValue = clampMaxValue(maxValue)
+	return &PPM{
+		Pix:         make([]Pixel, width*height),
+		Stride:      width,
+		Rect:        image.Rect(0, 0, width, height),
+		magicNumber: magicNumber,
+		max:         maxValue,
+	}
+}
+
+// PPMFromImage convertit une image quelconque en image PPM, les composantes
+// étant ramenées sur la plage 0..maxValue. Comme pour NewPPM, maxValue est
+// ramenée dans la plage 1..65535.
+
+func PPMFromImage(img image.Image, maxValue int, magicNumber string) *PPM {
+	bounds := img.Bounds()
+	maxValue = clampMaxValue(maxValue)
+	ppm := NewPPM(bounds.Dx(), bounds.Dy(), maxValue, magicNumber)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			ppm.Pix[y*ppm.Stride+x] = Pixel{
+				R: scaleSample(uint16(r), maxValue),
+				G: scaleSample(uint16(g), maxValue),
+				B: scaleSample(uint16(b), maxValue),
+			}
+		}
+	}
+	return ppm
+}
+
+// ReadPPM lit une image PPM à partir d'un fichier et renvoie un objet PPM.
+
+func ReadPPM(fileName string) (*PPM, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodePPM(file)
+}
+
+// DecodePPM lit une image PPM depuis r et renvoie un objet PPM.
+
+func DecodePPM(r io.Reader) (*PPM, error) {
+	return DecodePPMWithOptions(r, nil)
+}
+
+// DecodePPMWithOptions lit une image PPM depuis r avec les options de décodage
+// opts, qui peuvent être nil.
+
+func DecodePPMWithOptions(r io.Reader, opts *DecodeOptions) (*PPM, error) {
+	rr, err := newPPMRowReader(pnm.NewReaderOptions(r, opts), false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pix grandit au fil des lignes lues, voir rasterCap
+	ppm := NewPPM(0, 0, rr.max, rr.magicNumber)
+	ppm.comments = rr.comments
+	ppm.Stride = rr.width
+	ppm.Pix = make([]Pixel, 0, rasterCap(rr.width*rr.height))
+	for i := 0; i < rr.height; i++ {
+		row, err := rr.ReadRow()
+		if err != nil {
+			return nil, err
+		}
+		ppm.Pix = append(ppm.Pix, row...)
+	}
+	if err := rr.hr.CheckTrailing("ppm"); err != nil {
+		return nil, err
+	}
+	ppm.Rect = image.Rect(0, 0, rr.width, rr.height)
+	return ppm, nil
+}
+
+// readPPMHeader lit l'en-tête PPM : numéro magique, dimensions et valeur maximale.
+
+func readPPMHeader(hr *pnm.Reader) (magicNumber string, width, height, maxVal int, err error) {
+	magicNumber, err = hr.Token()
+	if err != nil || (magicNumber != "P3" && magicNumber != "P6") {
+		return "", 0, 0, 0, hr.HeaderError("ppm", pnm.ErrBadMagic, strconv.Quote(magicNumber))
+	}
+
+	width, err = hr.Int()
+	if err != nil || width < 1 {
+		return "", 0, 0, 0, hr.HeaderError("ppm", pnm.ErrBadHeader, "invalid width")
+	}
+
+	height, err = hr.Int()
+	if err != nil || height < 1 {
+		return "", 0, 0, 0, hr.HeaderError("ppm", pnm.ErrBadHeader, "invalid height")
+	}
+
+	maxVal, err = hr.Int()
+	if err != nil || maxVal < 1 {
+		return "", 0, 0, 0, hr.HeaderError("ppm", pnm.ErrBadHeader, "invalid max value")
+	}
+
+	if maxVal > 65535 {
+		return "", 0, 0, 0, hr.HeaderError("ppm", pnm.ErrUnsupported, fmt.Sprintf("max value %d", maxVal))
+	}
+	return magicNumber, width, height, maxVal, nil
+}
+
+// decodePPMConfig lit uniquement l'en-tête d'une image PPM depuis r.
+
+func decodePPMConfig(r io.Reader) (image.Config, error) {
+	_, width, height, maxVal, err := readPPMHeader(pnm.NewReader(r))
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: ppmColorModel(maxVal), Width: width, Height: height}, nil
+}
+
+// init enregistre le format auprès du paquet image, pour image.Decode et image.DecodeConfig.
+
+func init() {
+	decode := func(r io.Reader) (image.Image, error) {
+		ppm, err := DecodePPM(r)
+		if err != nil {
+			return nil, err
+		}
+		return ppm, nil
+	}
+	image.RegisterFormat("ppm", "P3", decode, decodePPMConfig)
+	image.RegisterFormat("ppm", "P6", decode, decodePPMConfig)
+}
+
+// ppmColorModel renvoie color.RGBAModel si la valeur maximale est 255, color.RGBA64Model sinon.
+
+func ppmColorModel(maxVal int) color.Model {
+	if maxVal == 255 {
+		return color.RGBAModel
+	}
+	return color.RGBA64Model
+}
+
+// Size renvoie la largeur et la hauteur de l'image PPM.
+
+func (ppm *PPM) Size() (int, int) {
+	return ppm.Rect.Dx(), ppm.Rect.Dy()
+}
+
+// Format renvoie le numéro magique de l'image PPM.
+
+func (ppm *PPM) Format() string {
+	return ppm.magicNumber
+}
+
+// Comments renvoie les commentaires de l'en-tête de l'image PPM, dans l'ordre
+// du fichier.
+
+func (ppm *PPM) Comments() []string {
+	return append([]string(nil), ppm.comments...)
+}
+
+// SetComments définit les commentaires écrits dans l'en-tête par Save et Encode.
+
+func (ppm *PPM) SetComments(comments []string) {
+	ppm.comments = append([]string(nil), comments...)
+}
+
+// Metadata renvoie la valeur d'une métadonnée key=valeur rangée dans les
+// commentaires.
+
+func (ppm *PPM) Metadata(key string) (string, bool) {
+	return pnm.Metadata(ppm.comments, key)
+}
+
+// SetMetadata ajoute ou remplace le commentaire "key=value" de l'en-tête.
+
+func (ppm *PPM) SetMetadata(key, value string) {
+	ppm.comments = pnm.SetMetadata(ppm.Comments(), key, value)
+}
+
+// MaxValue renvoie la valeur maximale d'un échantillon.
+
+func (ppm *PPM) MaxValue() int {
+	return ppm.max
+}
+
+// Samples renvoie les échantillons rouge, vert et bleu du pixel aux coordonnées spécifiées.
+
+func (ppm *PPM) Samples(x, y int) []uint16 {
+	p := ppm.PixelAt(x, y)
+	return []uint16{p.R, p.G, p.B}
+}
+
+// PixOffset renvoie l'indice dans Pix du pixel (x, y).
+
+func (ppm *PPM) PixOffset(x, y int) int {
+	return (y-ppm.Rect.Min.Y)*ppm.Stride + (x - ppm.Rect.Min.X)
+}
+
+// row renvoie la ligne y de l'image, qui partage la mémoire de Pix.
+
+func (ppm *PPM) row(y int) []Pixel {
+	i := ppm.PixOffset(ppm.Rect.Min.X, ppm.Rect.Min.Y+y)
+	return ppm.Pix[i : i+ppm.Rect.Dx()]
+}
+
+// PixelAt renvoie la couleur du pixel aux coordonnées spécifiées, ou un pixel
+// noir hors de l'image.
+
+func (ppm *PPM) PixelAt(x, y int) Pixel {
+	if !(image.Point{x, y}.In(ppm.Rect)) {
+		return Pixel{}
+	}
+	return ppm.Pix[ppm.PixOffset(x, y)]
+}
+
+// ColorModel renvoie le modèle de couleur de l'image PPM.
+
+func (ppm *PPM) ColorModel() color.Model {
+	return ppmColorModel(ppm.max)
+}
+
+// Bounds renvoie le rectangle occupé par l'image PPM.
+
+func (ppm *PPM) Bounds() image.Rectangle {
+	return ppm.Rect
+}
+
+// At renvoie la couleur du pixel aux coordonnées spécifiées, ramenée sur toute
+// la plage 8 ou 16 bits.
+
+func (ppm *PPM) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(ppm.Rect)) {
+		return color.RGBA{}
+	}
+	p := ppm.Pix[ppm.PixOffset(x, y)]
+	if ppm.max == 255 {
+		return color.RGBA{R: uint8(p.R), G: uint8(p.G), B: uint8(p.B), A: 0xff}
+	}
+	scale := func(v uint16) uint16 {
+		return uint16(uint32(v) * 0xffff / uint32(ppm.max))
+	}
+	return color.RGBA64{R: scale(p.R), G: scale(p.G), B: scale(p.B), A: 0xffff}
+}
+
+// Set définit la couleur du pixel aux coordonnées spécifiées avec la valeur de
+// couleur donnée. Les coordonnées hors de l'image sont ignorées.
+
+func (ppm *PPM) Set(x, y int, value Pixel) {
+	if !(image.Point{x, y}.In(ppm.Rect)) {
+		return
+	}
+	ppm.Pix[ppm.PixOffset(x, y)] = value
+}
+
+// SubImage renvoie la partie de l'image PPM contenue dans r. L'image renvoyée
+// partage ses pixels avec l'image d'origine.
+
+func (ppm *PPM) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(ppm.Rect)
+	// Une image vide ne partage rien : Pix[i:] pourrait sortir du tableau.
+	if r.Empty() {
+		return &PPM{magicNumber: ppm.magicNumber, max: ppm.max, comments: ppm.comments}
+	}
+	i := ppm.PixOffset(r.Min.X, r.Min.Y)
+	return &PPM{
+		Pix:         ppm.Pix[i:],
+		Stride:      ppm.Stride,
+		Rect:        r,
+		magicNumber: ppm.magicNumber,
+		max:         ppm.max,
+		comments:    ppm.comments,
+	}
+}
+
+// Save enregistre l'image PPM dans un fichier.
+
+func (ppm *PPM) Save(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	if err := ppm.Encode(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Encode écrit l'image PPM dans w.
+
+func (ppm *PPM) Encode(w io.Writer) error {
+	width, height := ppm.Size()
+	rw, err := NewPPMRowWriterComments(w, width, height, ppm.max, ppm.magicNumber, ppm.comments)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < height; i++ {
+		if err := rw.WriteRow(ppm.row(i)); err != nil {
+			return err
+		}
+	}
+	return rw.Close()
+}
+
+func (ppm *PPM) Invert() {
+	for i := 0; i < ppm.Rect.Dy(); i++ {
+		InvertPPMRow(ppm.row(i), ppm.max)
+	}
+}
+
+func (ppm *PPM) Flip() {
+	for i := 0; i < ppm.Rect.Dy(); i++ {
+		FlipPPMRow(ppm.row(i))
+	}
+}
+
+func (ppm *PPM) Flop() {
+	height := ppm.Rect.Dy()
+	for i := 0; i < height/2; i++ {
+		top, bottom := ppm.row(i), ppm.row(height-i-1)
+		for j := range top {
+			top[j], bottom[j] = bottom[j], top[j]
+		}
+	}
+}
+
+func (ppm *PPM) SetMagicNumber(magicNumber string) {
+	ppm.magicNumber = magicNumber
+}
+
+func (ppm *PPM) SetMaxValue(maxValue uint16) {
+	ppm.max = clampMaxValue(int(maxValue))
+}
+
+func (ppm *PPM) Rotate90CW() {
+	ppm.turn(turn90CW)
+}
+
+// Rotate90CCW fait pivoter l'image de 90 degrés dans le sens inverse des
+// aiguilles d'une montre.
+
+func (ppm *PPM) Rotate90CCW() {
+	ppm.turn(turn90CCW)
+}
+
+// Rotate180 fait pivoter l'image d'un demi-tour.
+
+func (ppm *PPM) Rotate180() {
+	ppm.turn(turn180)
+}
+
+// Transpose reflète l'image par rapport à sa diagonale principale, qui va du
+// coin supérieur gauche au coin inférieur droit.
+
+func (ppm *PPM) Transpose() {
+	ppm.turn(transpose)
+}
+
+// Transverse reflète l'image par rapport à son autre diagonale, qui va du
+// coin supérieur droit au coin inférieur gauche.
+
+func (ppm *PPM) Transverse() {
+	ppm.turn(transverse)
+}
+
+// RotateAngle fait pivoter l'image de degrees degrés dans le sens des
+// aiguilles d'une montre autour de son centre. L'image est agrandie pour
+// contenir toute l'image tournée, et les coins ajoutés prennent la couleur
+// background. Les multiples de 90 degrés sont exacts, sans interpolation ; un
+// angle infini ou NaN laisse l'image inchangée.
+
+func (ppm *PPM) RotateAngle(degrees float64, background Pixel, interpolation Interpolation) {
+	if t, ok := quarterTurns(degrees); ok {
+		if t.src != nil {
+			ppm.turn(t)
+		}
+		return
+	}
+	width, height := ppm.Size()
+	r := newRotation(width, height, degrees)
+	// Une fonction d'échantillonnage par composante
+	var samples [3]func(x, y int) float64
+	for c := range samples {
+		c := c
+		samples[c] = func(x, y int) float64 {
+			p := background
+			if x >= 0 && y >= 0 && x < width && y < height {
+				p = ppm.row(y)[x]
+			}
+			return float64([3]uint16{p.R, p.G, p.B}[c])
+		}
+	}
+	pix := make([]Pixel, r.outWidth*r.outHeight)
+	for y := 0; y < r.outHeight; y++ {
+		for x := 0; x < r.outWidth; x++ {
+			u, v := r.source(x, y)
+			pix[y*r.outWidth+x] = Pixel{
+				R: interpolate(interpolation, u, v, ppm.max, samples[0]),
+				G: interpolate(interpolation, u, v, ppm.max, samples[1]),
+				B: interpolate(interpolation, u, v, ppm.max, samples[2]),
+			}
+		}
+	}
+	ppm.Pix, ppm.Stride, ppm.Rect = pix, r.outWidth, image.Rect(0, 0, r.outWidth, r.outHeight)
+}
+
+// turn remplace l'image par sa transformée par t ; l'image obtenue commence
+// en (0, 0).
+
+func (ppm *PPM) turn(t quarterTurn) {
+	width, height := ppm.Size()
+	w, h := t.size(width, height)
+	pix := make([]Pixel, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sx, sy := t.src(x, y, width, height)
+			pix[y*w+x] = ppm.row(sy)[sx]
+		}
+	}
+	ppm.Pix, ppm.Stride, ppm.Rect = pix, w, image.Rect(0, 0, w, h)
+}
+
+// ToPGM convertit l'image PPM en image PGM P2, en moyennant les trois
+// composantes. Les commentaires de l'en-tête sont conservés.
+
+func (ppm *PPM) ToPGM() *PGM {
+	width, height := ppm.Size()
+	pgm := NewPGM(width, height, ppm.max, "P2")
+	pgm.comments = ppm.Comments()
+	for i := 0; i < height; i++ {
+		for j, p := range ppm.row(i) {
+			grayValue := uint16((uint32(p.R) + uint32(p.G) + uint32(p.B)) / 3)
+			pgm.Set(j, i, grayValue)
+		}
+	}
+	return pgm
+}
+
+// ToPBM convertit l'image PPM en image PBM P1 : un pixel devient noir lorsque
+// la moyenne de ses trois composantes est inférieure à la moitié de la valeur
+// maximale. Les commentaires de l'en-tête sont conservés.
+
+func (ppm *PPM) ToPBM() *PBM {
+	width, height := ppm.Size()
+	pbm := NewPBM(width, height, "P1")
+	pbm.comments = ppm.Comments()
+	for i := 0; i < height; i++ {
+		for j, p := range ppm.row(i) {
+			// Moyenne des composantes comparée à max/2, sans division entière
+			sum := int(p.R) + int(p.G) + int(p.B)
+			if 2*sum < 3*ppm.max {
+				pbm.Set(j, i, true)
+			}
+		}
+	}
+	return pbm
+}
+
+func (ppm *PPM) DrawLine(p1, p2 Point, color Pixel) {
+	x0, y0 := p1.X, p1.Y
+	x1, y1 := p2.X, p2.Y
+
+	dx := abs(x1 - x0)
+	sx := x0
+	if x0 < x1 {
+		sx = 1
+	} else {
+		sx = -1
+	}
+	dy := -abs(y1 - y0)
+	sy := y0
+	if y0 < y1 {
+		sy = 1
+	} else {
+		sy = -1
+	}
+	err := dx + dy
+	e2 := 0
+
+	for {
+		ppm.Set(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			break
+		}
+		e2 = 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+
+}
+
+func (ppm *PPM) DrawRectangle(p1 Point, width, height int, color Pixel) {
+	ppm.DrawLine(p1, Point{X: p1.X + width, Y: p1.Y}, color)
+	ppm.DrawLine(Point{X: p1.X, Y: p1.Y + height}, Point{X: p1.X + width, Y: p1.Y + height}, color)
+	ppm.DrawLine(p1, Point{X: p1.X, Y: p1.Y + height}, color)
+	ppm.DrawLine(Point{X: p1.X + width, Y: p1.Y}, Point{X: p1.X + width, Y: p1.Y + height}, color)
+}
+
+func (ppm *PPM) DrawFilledRectangle(p1 Point, width, height int, color Pixel) {
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			ppm.Set(p1.X+x, p1.Y+y, color)
+		}
+	}
+}
+
+func (ppm *PPM) DrawCircle(center Point, radius int, color Pixel) {
+	x := radius
+	y := 0
+	err := 0
+
+	for x >= y {
+		ppm.Set(center.X+x, center.Y+y, color)
+		ppm.Set(center.X+y, center.Y+x, color)
+		ppm.Set(center.X-y, center.Y+x, color)
+		ppm.Set(center.X-x, center.Y+y, color)
+		ppm.Set(center.X-x, center.Y-y, color)
+		ppm.Set(center.X-y, center.Y-x, color)
+		ppm.Set(center.X+y, center.Y-x, color)
+		ppm.Set(center.X+x, center.Y-y, color)
+
+		y += 1
+		if err <= 0 {
+			err += 2*y + 1
+		} else {
+			x -= 1
+			err += 2*(y-x) + 1
+		}
+	}
+}
+
+func (ppm *PPM) DrawFilledCircle(center Point, radius int, color Pixel) {
+	x := radius
+	y := 0
+	err := 0
+
+	for x >= y {
+		ppm.fillScanline(center.X-y, center.X+y, center.Y+x, color)
+		ppm.fillScanline(center.X-y, center.X+y, center.Y-x, color)
+		ppm.fillScanline(center.X-x, center.X+x, center.Y+y, color)
+		ppm.fillScanline(center.X-x, center.X+x, center.Y-y, color)
+
+		y += 1
+		if err <= 0 {
+			err += 2*y + 1
+		} else {
+			x -= 1
+			err += 2*(y-x) + 1
+		}
+	}
+}
+
+func (ppm *PPM) fillScanline(x1, x2, y int, color Pixel) {
+	if y < ppm.Rect.Min.Y || y >= ppm.Rect.Max.Y {
+		return
+	}
+
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+
+	for x := x1; x <= x2; x++ {
+		if x >= ppm.Rect.Min.X && x < ppm.Rect.Max.X {
+			ppm.Set(x, y, color)
+		}
+	}
+}
+
+func (ppm *PPM) DrawTriangle(p1, p2, p3 Point, color Pixel) {
+	vertices := []Point{p1, p2, p3}
+	sort.Slice(vertices, func(i, j int) bool {
+		return vertices[i].Y < vertices[j].Y
+	})
+
+	slope1 := float64(vertices[1].X-vertices[0].X) / float64(vertices[1].Y-vertices[0].Y)
+	slope2 := float64(vertices[2].X-vertices[0].X) / float64(vertices[2].Y-vertices[0].Y)
+	slope3 := float64(vertices[2].X-vertices[1].X) / float64(vertices[2].Y-vertices[1].Y)
+
+	x1 := float64(vertices[0].X)
+	x2 := float64(vertices[0].X)
+
+	for y := vertices[0].Y; y <= vertices[1].Y; y++ {
+		ppm.fillScanline(int(x1), int(x2), y, color)
+		x1 += slope1
+		x2 += slope2
+	}
+
+	x1 = float64(vertices[1].X)
+	x2 = float64(vertices[0].X)
+
+	for y := vertices[1].Y + 1; y <= vertices[2].Y; y++ {
+		ppm.fillScanline(int(x1), int(x2), y, color)
+		x1 += slope3
+		x2 += slope2
+	}
+}
+
+func (ppm *PPM) calculateSlope(p1, p2 Point) float64 {
+	if p2.Y-p1.Y == 0 {
+		return 0
+	}
+	return float64(p2.X-p1.X) / float64(p2.Y-p1.Y)
+}
+
+func (ppm *PPM) DrawFilledTriangle(p1, p2, p3 Point, color Pixel) {
+	vertices := []Point{p1, p2, p3}
+	sort.Slice(vertices, func(i, j int) bool {
+		return vertices[i].Y < vertices[j].Y
+	})
+
+	slope1 := ppm.calculateSlope(vertices[0], vertices[1])
+	slope2 := ppm.calculateSlope(vertices[0], vertices[2])
+	slope3 := ppm.calculateSlope(vertices[1], vertices[2])
+
+	x1 := float64(vertices[0].X)
+	x2 := float64(vertices[0].X)
+
+	for y := vertices[0].Y; y <= vertices[1].Y; y++ {
+		ppm.fillScanline(int(x1), int(x2), y, color)
+		x1 += slope1
+		x2 += slope2
+	}
+
+	x1 = float64(vertices[1].X)
+	x2 = float64(vertices[0].X)
+
+	for y := vertices[1].Y + 1; y <= vertices[2].Y; y++ {
+		ppm.fillScanline(int(x1), int(x2), y, color)
+		x1 += slope3
+		x2 += slope2
+	}
+}
+
+func (ppm *PPM) DrawPolygon(points []Point, color Pixel) {
+	numPoints := len(points)
+
+	for i := 0; i < numPoints-1; i++ {
+		ppm.DrawLine(points[i], points[i+1], color)
+	}
+
+	ppm.DrawLine(points[numPoints-1], points[0], color)
+}
+
+func (ppm *PPM) DrawFilledPolygon(points []Point, color Pixel) {
+	numPoints := len(points)
+
+	minY, maxY := points[0].Y, points[0].Y
+	for i := 1; i < numPoints; i++ {
+		if points[i].Y < minY {
+			minY = points[i].Y
+		} else if points[i].Y > maxY {
+			maxY = points[i].Y
+		}
+	}
+
+	for y := minY; y <= maxY; y++ {
+		intersectPoints := []Point{}
+		for i := 0; i < numPoints; i++ {
+			if (points[i].Y <= y && points[(i+1)%numPoints].Y > y) ||
+				(points[i].Y > y && points[(i+1)%numPoints].Y <= y) {
+				x := int(float64(points[i].X) + float64(y-points[i].Y)/float64(points[(i+1)%numPoints].Y-points[i].Y)*float64(points[(i+1)%numPoints].X-points[i].X))
+				intersectPoints = append(intersectPoints, Point{X: x, Y: y})
+			}
+		}
+
+		sort.Slice(intersectPoints, func(i, j int) bool {
+			return intersectPoints[i].X < intersectPoints[j].X
+		})
+
+		for i := 0; i < len(intersectPoints)-1; i += 2 {
+			ppm.DrawLine(intersectPoints[i], intersectPoints[i+1], color)
+		}
+	}
+}
+
+func (ppm *PPM) DrawKochSnowflake(center Point, radius, depth int, color Pixel) {
+
+	p1 := Point{center.X, center.Y - radius}
+	cos30 := math.Cos(math.Pi / 6)
+	sin30 := math.Sin(math.Pi / 6)
+	p2 := Point{center.X + int(float64(radius)*cos30), center.Y + int(float64(radius)*sin30)}
+	p3 := Point{center.X - int(float64(radius)*cos30), center.Y + int(float64(radius)*sin30)}
+
+	ppm.drawKochSegment(p1, p2, depth, color)
+	ppm.drawKochSegment(p2, p3, depth, color)
+	ppm.drawKochSegment(p3, p1, depth, color)
+}
+
+func (ppm *PPM) drawKochSegment(p1, p2 Point, depth int, color Pixel) {
+	if depth == 0 {
+		ppm.DrawLine(p1, p2, color)
+	} else {
+
+		p3 := calculateKochIntermediate(p1, p2, 1.0/3.0)
+		p4 := calculateKochIntermediate(p1, p2, 2.0/3.0)
+
+		tip := calculateKochTip(p1, p2)
+
+		ppm.drawKochSegment(p1, p3, depth-1, color)
+		ppm.drawKochSegment(p3, tip, depth-1, color)
+		ppm.drawKochSegment(tip, p4, depth-1, color)
+		ppm.drawKochSegment(p4, p2, depth-1, color)
+	}
+}
+
+func calculateKochIntermediate(p1, p2 Point, ratio float64) Point {
+	return Point{
+		X: p1.X + int(float64(p2.X-p1.X)*ratio),
+		Y: p1.Y + int(float64(p2.Y-p1.Y)*ratio),
+	}
+}
+
+func calculateKochTip(p1, p2 Point) Point {
+	angle := math.Pi / 3.0
+	x := int(float64(p1.X) + float64(p2.X-p1.X)*math.Cos(angle) - float64(p2.Y-p1.Y)*math.Sin(angle))
+	y := int(float64(p1.Y) + float64(p2.X-p1.X)*math.Sin(angle) + float64(p2.Y-p1.Y)*math.Cos(angle))
+	return Point{X: x, Y: y}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
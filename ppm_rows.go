@@ -0,0 +1,275 @@
+package netpbm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"Netbpm/internal/pnm"
+)
+
+// PPMRowReader lit une image PPM ligne par ligne, sans jamais garder plus d'une
+// ligne en mémoire.
+
+type PPMRowReader struct {
+	hr            *pnm.Reader
+	magicNumber   string
+	comments      []string // commentaires de l'en-tête
+	width, height int
+	max           int
+	y             int      // indice de la prochaine ligne à lire
+	row           []Pixel  // ligne renvoyée par ReadRow, réutilisée d'un appel à l'autre
+	samples       []uint16 // échantillons d'une ligne P6
+	raw           []byte   // octets bruts d'une ligne P6
+	truncated     bool     // fin des données atteinte en mode Lenient, les lignes suivantes sont noires
+}
+
+// NewPPMRowReader lit l'en-tête PPM depuis r et renvoie un lecteur positionné sur
+// la première ligne.
+
+func NewPPMRowReader(r io.Reader) (*PPMRowReader, error) {
+	return NewPPMRowReaderOptions(r, nil)
+}
+
+// NewPPMRowReaderOptions fait comme NewPPMRowReader, avec les options de décodage
+// opts, qui peuvent être nil.
+
+func NewPPMRowReaderOptions(r io.Reader, opts *DecodeOptions) (*PPMRowReader, error) {
+	return newPPMRowReader(pnm.NewReaderOptions(r, opts), true)
+}
+
+// newPPMRowReader lit l'en-tête PPM depuis hr. Si rows est vrai, seules les
+// limites propres à la lecture ligne par ligne sont vérifiées.
+
+func newPPMRowReader(hr *pnm.Reader, rows bool) (*PPMRowReader, error) {
+	magicNumber, width, height, maxVal, err := readPPMHeader(hr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Les limites sont vérifiées avant toute allocation : trois échantillons
+	// d'un ou deux octets par pixel en P6, d'au moins un caractère en P3.
+	bits := 8
+	if magicNumber == "P6" {
+		bits = 8 * pnm.SampleSize(maxVal)
+	}
+	check := hr.CheckSize
+	if rows {
+		check = hr.CheckRowSize
+	}
+	if err := check("ppm", width, height, 3, bits); err != nil {
+		return nil, err
+	}
+	return &PPMRowReader{
+		hr:          hr,
+		magicNumber: magicNumber,
+		comments:    hr.Comments(),
+		width:       width,
+		height:      height,
+		max:         maxVal,
+		row:         make([]Pixel, width),
+	}, nil
+}
+
+// Size renvoie la largeur et la hauteur de l'image.
+
+func (rr *PPMRowReader) Size() (int, int) {
+	return rr.width, rr.height
+}
+
+// Comments renvoie les commentaires de l'en-tête, dans l'ordre du fichier.
+
+func (rr *PPMRowReader) Comments() []string {
+	return rr.comments
+}
+
+// Format renvoie le numéro magique de l'image.
+
+func (rr *PPMRowReader) Format() string {
+	return rr.magicNumber
+}
+
+// MaxValue renvoie la valeur maximale d'un échantillon.
+
+func (rr *PPMRowReader) MaxValue() int {
+	return rr.max
+}
+
+// ReadRow lit la ligne suivante. La tranche renvoyée est réutilisée par l'appel
+// suivant ; io.EOF est renvoyé une fois toutes les lignes lues.
+
+func (rr *PPMRowReader) ReadRow() ([]Pixel, error) {
+	if rr.y >= rr.height {
+		return nil, io.EOF
+	}
+	i := rr.y
+
+	// Après la fin des données en mode Lenient, les lignes manquantes sont noires
+	clear(rr.row)
+	if rr.truncated {
+		rr.y++
+		return rr.row, nil
+	}
+
+	if rr.magicNumber == "P6" {
+		// Trois échantillons d'un ou deux octets par pixel, sans séparateur
+		if rr.raw == nil {
+			rr.raw = make([]byte, 3*rr.width*pnm.SampleSize(rr.max))
+			rr.samples = make([]uint16, 3*rr.width)
+		}
+		truncated, err := rr.hr.ReadRaster("ppm", rr.raw, i)
+		if err != nil {
+			return nil, err
+		}
+		rr.truncated = truncated
+		pnm.DecodeSamples(rr.samples, rr.raw, rr.max)
+		if err := rr.hr.CheckSamples("ppm", rr.samples, i, 3, rr.max); err != nil {
+			return nil, err
+		}
+		for j := range rr.row {
+			rr.row[j] = Pixel{R: rr.samples[3*j], G: rr.samples[3*j+1], B: rr.samples[3*j+2]}
+		}
+	} else {
+		// Une suite d'entiers, sans tenir compte des retours à la ligne
+		channels := [3]string{"red value", "green value", "blue value"}
+	pixels:
+		for j := range rr.row {
+			var values [3]uint16
+			for k := range values {
+				v, err := rr.hr.Sample("ppm", channels[k], j, i, rr.max)
+				if rr.hr.Recover(err) {
+					// Les composantes déjà lues du dernier pixel sont conservées
+					rr.truncated = true
+					rr.row[j] = Pixel{R: values[0], G: values[1], B: values[2]}
+					break pixels
+				}
+				if err != nil {
+					return nil, err
+				}
+				values[k] = v
+			}
+			rr.row[j] = Pixel{R: values[0], G: values[1], B: values[2]}
+		}
+	}
+
+	rr.y++
+	return rr.row, nil
+}
+
+// PPMRowWriter écrit une image PPM ligne par ligne.
+
+type PPMRowWriter struct {
+	writer        *bufio.Writer
+	magicNumber   string
+	width, height int
+	max           int
+	y             int      // nombre de lignes déjà écrites
+	samples       []uint16 // échantillons d'une ligne, vérifiés avant écriture
+	raw           []byte   // octets bruts d'une ligne P6
+}
+
+// NewPPMRowWriter écrit l'en-tête PPM dans w et renvoie un écrivain prêt à
+// recevoir la première ligne.
+
+func NewPPMRowWriter(w io.Writer, width, height, maxValue int, magicNumber string) (*PPMRowWriter, error) {
+	return NewPPMRowWriterComments(w, width, height, maxValue, magicNumber, nil)
+}
+
+// NewPPMRowWriterComments fait comme NewPPMRowWriter, en écrivant aussi les
+// commentaires comments dans l'en-tête, juste après le numéro magique.
+
+func NewPPMRowWriterComments(w io.Writer, width, height, maxValue int, magicNumber string, comments []string) (*PPMRowWriter, error) {
+	if magicNumber != "P3" && magicNumber != "P6" {
+		return nil, fmt.Errorf("%w: PPM magic number %q", pnm.ErrUnsupported, magicNumber)
+	}
+	if maxValue < 1 || maxValue > 65535 {
+		return nil, fmt.Errorf("%w: PPM maxval %d, must be between 1 and 65535", pnm.ErrUnsupported, maxValue)
+	}
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "%s\n", magicNumber)
+	if err := pnm.WriteComments(writer, comments); err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(writer, "%d %d\n%d\n", width, height, maxValue); err != nil {
+		return nil, err
+	}
+	return &PPMRowWriter{
+		writer:      writer,
+		magicNumber: magicNumber,
+		width:       width,
+		height:      height,
+		max:         maxValue,
+	}, nil
+}
+
+// WriteRow écrit la ligne suivante, qui doit contenir width pixels dont
+// aucune composante ne dépasse la valeur maximale (sinon ErrSampleOutOfRange).
+
+func (rw *PPMRowWriter) WriteRow(row []Pixel) error {
+	if rw.y >= rw.height {
+		return errors.New("netpbm: ppm: all rows already written")
+	}
+	if len(row) != rw.width {
+		return fmt.Errorf("netpbm: ppm: row %d has %d pixels, want %d", rw.y, len(row), rw.width)
+	}
+
+	if rw.samples == nil {
+		rw.samples = make([]uint16, 3*rw.width)
+	}
+	for j, pixel := range row {
+		rw.samples[3*j], rw.samples[3*j+1], rw.samples[3*j+2] = pixel.R, pixel.G, pixel.B
+	}
+	if err := pnm.CheckRange("ppm", rw.samples, rw.y, 3, rw.max); err != nil {
+		return err
+	}
+
+	if rw.magicNumber == "P6" {
+		if rw.raw == nil {
+			rw.raw = make([]byte, 3*rw.width*pnm.SampleSize(rw.max))
+		}
+		pnm.EncodeSamples(rw.raw, rw.samples, rw.max)
+		if _, err := rw.writer.Write(rw.raw); err != nil {
+			return err
+		}
+	} else {
+		for _, pixel := range row {
+			if _, err := fmt.Fprintf(rw.writer, "%d %d %d\n", pixel.R, pixel.G, pixel.B); err != nil {
+				return err
+			}
+		}
+	}
+
+	rw.y++
+	return nil
+}
+
+// Close vide le tampon d'écriture et vérifie que toutes les lignes ont été écrites.
+
+func (rw *PPMRowWriter) Close() error {
+	if err := rw.writer.Flush(); err != nil {
+		return err
+	}
+	if rw.y != rw.height {
+		return fmt.Errorf("netpbm: ppm: only %d of %d rows written", rw.y, rw.height)
+	}
+	return nil
+}
+
+// InvertPPMRow inverse les couleurs d'une ligne de pixels.
+
+func InvertPPMRow(row []Pixel, maxValue int) {
+	for j := range row {
+		row[j].R = uint16(maxValue) - row[j].R
+		row[j].G = uint16(maxValue) - row[j].G
+		row[j].B = uint16(maxValue) - row[j].B
+	}
+}
+
+// FlipPPMRow inverse l'ordre des pixels d'une ligne.
+
+func FlipPPMRow(row []Pixel) {
+	for j := 0; j < len(row)/2; j++ {
+		row[j], row[len(row)-j-1] = row[len(row)-j-1], row[j]
+	}
+}
@@ -0,0 +1,340 @@
+package netpbm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"Netbpm/internal/pnm"
+)
+
+func TestReadPPMP6(t *testing.T) {
+	content := "P6\n2 2\n255\n\xff\x00\x0a\x0d\x20\x09\x00\x00\x00\x01\x02\x03"
+	ppm, err := ReadPPM(writeTestFile(t, "p6.ppm", content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Pixel{{255, 0, 10}, {13, 32, 9}, {0, 0, 0}, {1, 2, 3}}
+	for i, p := range want {
+		if ppm.PixelAt(i%2, i/2) != p {
+			t.Errorf("Wrong pixel at (%d, %d): got %v, want %v", i%2, i/2, ppm.PixelAt(i%2, i/2), p)
+		}
+	}
+
+	saved := filepath.Join(t.TempDir(), "save.ppm")
+	if err := ppm.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != content {
+		t.Errorf("Wrong P6 output: %q", out)
+	}
+}
+
+func TestReadPPMP6Truncated(t *testing.T) {
+	_, err := ReadPPM(writeTestFile(t, "p6.ppm", "P6 2 2 255\n\xff\x00\x0a\x0d\x20\x09\x00"))
+	var perr *pnm.ParseError
+	if !errors.Is(err, pnm.ErrTruncated) || !errors.As(err, &perr) || perr.Y != 1 || perr.Offset != 17 {
+		t.Errorf("Expected a truncated raster error, got %v", err)
+	}
+}
+
+func TestReadPPMP3Duck(t *testing.T) {
+	// duck.ppm contient quatre pixels par ligne
+	ppm, err := ReadPPM("duck.ppm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := ppm.Size(); w != 4 || h != 4 {
+		t.Fatalf("Wrong size: %dx%d", w, h)
+	}
+	if ppm.PixelAt(3, 0) != (Pixel{15, 0, 15}) || ppm.PixelAt(1, 1) != (Pixel{0, 15, 7}) {
+		t.Error("Wrong data")
+	}
+}
+
+func TestReadPPMP3Errors(t *testing.T) {
+	_, err := ReadPPM(writeTestFile(t, "p3.ppm", "P3 2 1 255\n1 2 3 4 x 6\n"))
+	var perr *pnm.ParseError
+	if !errors.Is(err, pnm.ErrBadSample) || !errors.As(err, &perr) || perr.X != 1 || perr.Line != 2 || perr.Offset != 19 {
+		t.Errorf("Expected an error citing pixel (1, 0), got %v", err)
+	}
+	_, err = ReadPPM(writeTestFile(t, "p3.ppm", "P3 2 1 255\n1 2 3 4 5"))
+	if !errors.Is(err, pnm.ErrTruncated) || !strings.Contains(err.Error(), "blue value at pixel (1, 0)") {
+		t.Errorf("Expected an error citing pixel (1, 0), got %v", err)
+	}
+	_, err = ReadPPM(writeTestFile(t, "p3.ppm", "P3\n2 1\n255\n1 2 3\n4 256 6\n"))
+	if !errors.Is(err, pnm.ErrSampleOutOfRange) || !errors.As(err, &perr) || perr.Line != 5 || perr.Offset != 19 {
+		t.Errorf("Expected an error citing line 5, got %v", err)
+	}
+}
+
+func TestReadPPM16Bit(t *testing.T) {
+	content := "P6\n1 1\n65535\n\xff\xff\x80\x00\x00\x01"
+	ppm, err := ReadPPM(writeTestFile(t, "p6.ppm", content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ppm.PixelAt(0, 0) != (Pixel{65535, 32768, 1}) {
+		t.Errorf("Wrong pixel: %v", ppm.PixelAt(0, 0))
+	}
+
+	saved := filepath.Join(t.TempDir(), "save.ppm")
+	if err := ppm.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != content {
+		t.Errorf("Wrong P6 output: %q", out)
+	}
+
+	pgm := ppm.ToPGM()
+	if pgm.max != 65535 || pgm.Pix[0] != (65535+32768+1)/3 {
+		t.Errorf("Wrong gray value: max %d, %d", pgm.max, pgm.Pix[0])
+	}
+}
+
+func TestDecodeEncodePPM(t *testing.T) {
+	content := "P3\n2 1\n15\n0 7 15\n15 0 3\n"
+	ppm, err := DecodePPM(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := ppm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != content {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestNewPPM(t *testing.T) {
+	ppm := NewPPM(3, 2, 255, "P6")
+	ppm.Set(2, 1, Pixel{1, 2, 3})
+	var buf bytes.Buffer
+	if err := ppm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "P6\n3 2\n255\n" + strings.Repeat("\x00", 15) + "\x01\x02\x03"
+	if buf.String() != want {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPPMMaxValueRange(t *testing.T) {
+	ppm := NewPPM(1, 1, 0, "P6")
+	if ppm.MaxValue() != 1 {
+		t.Errorf("Wrong maxval: %d", ppm.MaxValue())
+	}
+	ppm.At(0, 0)
+	if ppm = PPMFromImage(ppm, 70000, "P6"); ppm.MaxValue() != 65535 {
+		t.Errorf("Wrong maxval: %d", ppm.MaxValue())
+	}
+	for _, maxValue := range []int{0, 65536} {
+		if _, err := NewPPMRowWriter(io.Discard, 1, 1, maxValue, "P6"); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("maxval %d: expected ErrUnsupported, got %v", maxValue, err)
+		}
+	}
+}
+
+func TestRowReaderWriterPPM(t *testing.T) {
+	content := "P3\n2 2\n15\n0 7 15\n15 0 3\n1 2 3\n4 5 6\n"
+	rr, err := NewPPMRowReader(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	width, height := rr.Size()
+	var buf bytes.Buffer
+	rw, err := NewPPMRowWriter(&buf, width, height, rr.MaxValue(), "P6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		row, err := rr.ReadRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		InvertPPMRow(row, rr.MaxValue())
+		if err := rw.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "P6\n2 2\n15\n\x0f\x08\x00\x00\x0f\x0c\x0e\x0d\x0c\x0b\x0a\x09"
+	if buf.String() != want {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestRowReaderLimitsPPM(t *testing.T) {
+	// Une mosaïque de 60000×40000 pixels dépasse MaxPixels et MaxBytes par
+	// défaut, mais un lecteur ligne par ligne n'alloue qu'une ligne.
+	header := "P6 60000 40000 255\n"
+	rr, err := NewPPMRowReader(strings.NewReader(header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if width, height := rr.Size(); width != 60000 || height != 40000 {
+		t.Errorf("Wrong size: %dx%d", width, height)
+	}
+	if _, err := DecodePPM(strings.NewReader(header)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("DecodePPM: expected ErrTooLarge, got %v", err)
+	}
+	opts := &DecodeOptions{MaxPixels: 1 << 20}
+	if _, err := NewPPMRowReaderOptions(strings.NewReader(header), opts); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("MaxPixels: expected ErrTooLarge, got %v", err)
+	}
+	if _, err := NewPPMRowReader(strings.NewReader("P6 2000000 1 255\n")); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("MaxWidth: expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestPPMComments(t *testing.T) {
+	ppm := NewPPM(1, 1, 255, "P6")
+	ppm.SetComments([]string{"première ligne\nseconde ligne"})
+	ppm.SetMetadata("source", "scanner-07")
+
+	var buf bytes.Buffer
+	if err := ppm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P6\n# première ligne\n# seconde ligne\n# source=scanner-07\n1 1\n255\n\x00\x00\x00" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+	decoded, err := DecodePPM(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments := decoded.Comments()
+	if len(comments) != 3 || comments[2] != "source=scanner-07" {
+		t.Errorf("Wrong comments: %q", comments)
+	}
+}
+
+func TestPPMConversions(t *testing.T) {
+	ppm := NewPPM(2, 1, 255, "P3")
+	ppm.Set(1, 0, Pixel{R: 240, G: 240, B: 240})
+	ppm.SetComments([]string{"source=scanner-07"})
+
+	// Les conversions renvoient les vrais types PGM et PBM, avec toutes leurs méthodes
+	pgm := ppm.ToPGM()
+	pgm.Invert()
+	if pgm.GrayAt(0, 0) != 255 || pgm.GrayAt(1, 0) != 15 {
+		t.Errorf("Wrong gray values: %d %d", pgm.GrayAt(0, 0), pgm.GrayAt(1, 0))
+	}
+	pbm := pgm.ToPBM()
+	pbm.SetMagicNumber("P4")
+	var buf bytes.Buffer
+	if err := pbm.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "P4\n# source=scanner-07\n2 1\n\x40" {
+		t.Errorf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestPPMToPBMThreshold(t *testing.T) {
+	// Comme PAM.ToPBM et PBMFromImage, les pixels sombres deviennent noirs
+	ppm := NewPPM(3, 1, 255, "P6")
+	ppm.Set(0, 0, Pixel{R: 10, G: 20, B: 30})
+	ppm.Set(1, 0, Pixel{R: 255, G: 255, B: 255})
+	ppm.Set(2, 0, Pixel{R: 255, G: 0, B: 0})
+	pbm := ppm.ToPBM()
+	if !pbm.BitAt(0, 0) || pbm.BitAt(1, 0) || !pbm.BitAt(2, 0) {
+		t.Errorf("Wrong bits: %v %v %v", pbm.BitAt(0, 0), pbm.BitAt(1, 0), pbm.BitAt(2, 0))
+	}
+	if want := PBMFromImage(ppm, "P1"); !bytes.Equal(pbm.Pix, want.Pix) {
+		t.Errorf("ToPBM and PBMFromImage disagree: %08b, %08b", pbm.Pix, want.Pix)
+	}
+
+	// Avec une valeur maximale de 1, le noir reste noir
+	ppm = NewPPM(2, 1, 1, "P3")
+	ppm.Set(1, 0, Pixel{R: 1, G: 1, B: 1})
+	if pbm = ppm.ToPBM(); !pbm.BitAt(0, 0) || pbm.BitAt(1, 0) {
+		t.Errorf("maxval 1: wrong bits %v %v", pbm.BitAt(0, 0), pbm.BitAt(1, 0))
+	}
+}
+
+func TestPPMRotations(t *testing.T) {
+	tests := []struct {
+		name   string
+		rotate func(ppm *PPM)
+		want   string
+	}{
+		{"Rotate90CW", (*PPM).Rotate90CW, "P6\n1 2\n9\n\x01\x02\x03\x04\x05\x06"},
+		{"Rotate90CCW", (*PPM).Rotate90CCW, "P6\n1 2\n9\n\x04\x05\x06\x01\x02\x03"},
+		{"Rotate180", (*PPM).Rotate180, "P6\n2 1\n9\n\x04\x05\x06\x01\x02\x03"},
+		{"Transpose", (*PPM).Transpose, "P6\n1 2\n9\n\x01\x02\x03\x04\x05\x06"},
+		{"Transverse", (*PPM).Transverse, "P6\n1 2\n9\n\x04\x05\x06\x01\x02\x03"},
+	}
+	for _, test := range tests {
+		ppm, err := DecodePPM(strings.NewReader("P6 2 1 9\n\x01\x02\x03\x04\x05\x06"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.rotate(ppm)
+		var buf bytes.Buffer
+		if err := ppm.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, buf.String(), test.want)
+		}
+	}
+}
+
+func TestPPMRotateAngle(t *testing.T) {
+	red, white := Pixel{255, 0, 0}, Pixel{255, 255, 255}
+	for _, interpolation := range []Interpolation{Nearest, Bilinear, Bicubic} {
+		ppm := NewPPM(10, 4, 255, "P6")
+		ppm.DrawFilledRectangle(Point{0, 0}, 10, 4, red)
+		ppm.RotateAngle(30, white, interpolation)
+
+		// 10·cos 30° + 4·sin 30° = 10,66 et 10·sin 30° + 4·cos 30° = 8,46
+		if w, h := ppm.Size(); w != 11 || h != 9 {
+			t.Fatalf("%d: wrong size %dx%d", interpolation, w, h)
+		}
+		if ppm.PixelAt(0, 0) != white || ppm.PixelAt(10, 8) != white {
+			t.Errorf("%d: corners must take the background color", interpolation)
+		}
+		if ppm.PixelAt(5, 4) != red {
+			t.Errorf("%d: wrong center %v", interpolation, ppm.PixelAt(5, 4))
+		}
+	}
+}
+
+func TestPPMEncodeSampleOutOfRange(t *testing.T) {
+	for _, magicNumber := range []string{"P6", "P3"} {
+		ppm := NewPPM(2, 1, 255, magicNumber)
+		ppm.Set(1, 0, Pixel{R: 10, G: 300, B: 10})
+		var buf bytes.Buffer
+		if err := ppm.Encode(&buf); !errors.Is(err, ErrSampleOutOfRange) {
+			t.Errorf("%s: got error %v, want ErrSampleOutOfRange", magicNumber, err)
+		}
+	}
+
+	// Une couleur de tracé supérieure à la valeur maximale est refusée à l'écriture
+	ppm := NewPPM(3, 3, 9, "P6")
+	ppm.DrawLine(Point{0, 0}, Point{2, 2}, Pixel{R: 10, G: 0, B: 0})
+	var buf bytes.Buffer
+	if err := ppm.Encode(&buf); !errors.Is(err, ErrSampleOutOfRange) {
+		t.Errorf("got error %v, want ErrSampleOutOfRange", err)
+	}
+}
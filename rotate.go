@@ -0,0 +1,137 @@
+package netpbm
+
+import "math"
+
+// Interpolation choisit comment RotateAngle calcule un pixel de l'image tournée
+// à partir des pixels voisins de l'image d'origine.
+type Interpolation int
+
+const (
+	// Nearest reprend le pixel d'origine le plus proche ; c'est la seule
+	// méthode possible pour une image PBM.
+	Nearest Interpolation = iota
+	// Bilinear mélange les quatre pixels les plus proches.
+	Bilinear
+	// Bicubic mélange les seize pixels les plus proches (noyau de Catmull-Rom),
+	// ce qui donne des bords plus nets que Bilinear.
+	Bicubic
+)
+
+// quarterTurn est une rotation par quart de tour ou une symétrie selon une
+// diagonale. src associe à un pixel (x, y) de l'image transformée le pixel de
+// l'image d'origine, de taille width×height, dont il provient.
+type quarterTurn struct {
+	swap bool // Les largeur et hauteur de l'image sont échangées
+	src  func(x, y, width, height int) (int, int)
+}
+
+var (
+	turn90CW   = quarterTurn{true, func(x, y, width, height int) (int, int) { return y, height - 1 - x }}
+	turn90CCW  = quarterTurn{true, func(x, y, width, height int) (int, int) { return width - 1 - y, x }}
+	turn180    = quarterTurn{false, func(x, y, width, height int) (int, int) { return width - 1 - x, height - 1 - y }}
+	transpose  = quarterTurn{true, func(x, y, width, height int) (int, int) { return y, x }}
+	transverse = quarterTurn{true, func(x, y, width, height int) (int, int) { return width - 1 - y, height - 1 - x }}
+)
+
+// size renvoie la taille de l'image width×height une fois transformée.
+func (t quarterTurn) size(width, height int) (int, int) {
+	if t.swap {
+		return height, width
+	}
+	return width, height
+}
+
+// quarterTurns renvoie la transformation équivalente à une rotation de
+// degrees degrés dans le sens des aiguilles d'une montre, lorsque degrees est
+// un multiple de 90. turn.src vaut nil pour un nombre entier de tours, et pour
+// un angle infini ou NaN, qui ne fait pas tourner l'image.
+func quarterTurns(degrees float64) (turn quarterTurn, ok bool) {
+	if math.IsNaN(degrees) || math.IsInf(degrees, 0) {
+		return quarterTurn{}, true
+	}
+	if math.Mod(degrees, 90) != 0 {
+		return quarterTurn{}, false
+	}
+	switch int(math.Mod(degrees/90, 4)+4) % 4 {
+	case 1:
+		return turn90CW, true
+	case 2:
+		return turn180, true
+	case 3:
+		return turn90CCW, true
+	}
+	return quarterTurn{}, true
+}
+
+// rotation décrit une rotation d'un angle quelconque autour du centre de
+// l'image, sur une toile agrandie pour contenir toute l'image tournée.
+type rotation struct {
+	width, height       int     // Taille de l'image d'origine
+	outWidth, outHeight int     // Taille de l'image tournée
+	cos, sin            float64 // Cosinus et sinus de l'angle
+}
+
+// newRotation prépare la rotation d'une image width×height de degrees degrés
+// dans le sens des aiguilles d'une montre.
+func newRotation(width, height int, degrees float64) rotation {
+	rad := degrees * math.Pi / 180
+	r := rotation{width: width, height: height, cos: math.Cos(rad), sin: math.Sin(rad)}
+	// La marge absorbe les erreurs d'arrondi, pour qu'un angle proche d'un
+	// multiple de 90 n'ajoute pas une ligne ou une colonne
+	w, h := float64(width), float64(height)
+	r.outWidth = int(math.Ceil(math.Abs(w*r.cos) + math.Abs(h*r.sin) - 1e-6))
+	r.outHeight = int(math.Ceil(math.Abs(w*r.sin) + math.Abs(h*r.cos) - 1e-6))
+	return r
+}
+
+// source renvoie la position, dans l'image d'origine, du centre du pixel
+// (x, y) de l'image tournée. Les centres des pixels d'origine sont aux
+// positions entières.
+func (r rotation) source(x, y int) (u, v float64) {
+	dx := float64(x) + 0.5 - float64(r.outWidth)/2
+	dy := float64(y) + 0.5 - float64(r.outHeight)/2
+	u = dx*r.cos + dy*r.sin + float64(r.width)/2 - 0.5
+	v = -dx*r.sin + dy*r.cos + float64(r.height)/2 - 0.5
+	return u, v
+}
+
+// taps renvoie le premier des n pixels d'origine qui contribuent, le long
+// d'un axe, à la position u, ainsi que le poids de chacun d'eux.
+func taps(interpolation Interpolation, u float64) (first int, weights [4]float64, n int) {
+	x0 := math.Floor(u)
+	f := u - x0
+	switch interpolation {
+	case Bilinear:
+		return int(x0), [4]float64{1 - f, f}, 2
+	case Bicubic:
+		return int(x0) - 1, [4]float64{cubic(f + 1), cubic(f), cubic(1 - f), cubic(2 - f)}, 4
+	}
+	return int(math.Floor(u + 0.5)), [4]float64{1}, 1
+}
+
+// cubic est le noyau de Catmull-Rom, nul au-delà de deux pixels.
+func cubic(t float64) float64 {
+	t = math.Abs(t)
+	switch {
+	case t < 1:
+		return 1.5*t*t*t - 2.5*t*t + 1
+	case t < 2:
+		return -0.5*t*t*t + 2.5*t*t - 4*t + 2
+	}
+	return 0
+}
+
+// interpolate calcule la valeur d'un canal à la position (u, v) de l'image
+// d'origine. sample renvoie la valeur du pixel (x, y), ou background hors de
+// l'image ; le résultat est arrondi et borné entre 0 et maxValue.
+func interpolate(interpolation Interpolation, u, v float64, maxValue int, sample func(x, y int) float64) uint16 {
+	x0, wx, n := taps(interpolation, u)
+	y0, wy, _ := taps(interpolation, v)
+	var sum float64
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			sum += wx[i] * wy[j] * sample(x0+i, y0+j)
+		}
+	}
+	return uint16(math.Max(0, math.Min(float64(maxValue), math.Round(sum))))
+}
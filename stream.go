@@ -0,0 +1,52 @@
+package netpbm
+
+import (
+	"bufio"
+	"io"
+
+	"Netbpm/internal/pnm"
+)
+
+// StreamReader lit une à une les images d'un flux Netpbm contenant plusieurs
+// images concaténées, éventuellement de formats différents, comme celui
+// produit par ffmpeg -f image2pipe -vcodec ppm.
+type StreamReader struct {
+	br *bufio.Reader
+}
+
+// NewStreamReader crée un StreamReader lisant depuis r.
+func NewStreamReader(r io.Reader) *StreamReader {
+	return &StreamReader{br: bufio.NewReader(r)}
+}
+
+// Next lit l'image suivante du flux. io.EOF est renvoyé lorsqu'il ne reste
+// plus que des blancs dans le flux.
+func (s *StreamReader) Next() (Image, error) {
+	// Les blancs séparant deux images sont ignorés
+	for {
+		b, err := s.br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if !pnm.IsSpace(b) {
+			s.br.UnreadByte()
+			break
+		}
+	}
+	return Decode(s.br)
+}
+
+// StreamWriter ajoute des images les unes à la suite des autres dans un flux.
+type StreamWriter struct {
+	w io.Writer
+}
+
+// NewStreamWriter crée un StreamWriter écrivant dans w.
+func NewStreamWriter(w io.Writer) *StreamWriter {
+	return &StreamWriter{w: w}
+}
+
+// Write ajoute img à la fin du flux, dans son propre format.
+func (s *StreamWriter) Write(img Image) error {
+	return img.Encode(s.w)
+}
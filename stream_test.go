@@ -0,0 +1,58 @@
+package netpbm
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreamReader(t *testing.T) {
+	stream := "P6\n1 1\n255\n\x0a\x20\x0d" +
+		"P1\n3 1\n101\n\n" +
+		"P5 2 1 255\n\x00\xff" +
+		"P3 1 1 9 1 2 3\n"
+	s := NewStreamReader(strings.NewReader(stream))
+	var formats []string
+	for {
+		img, err := s.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		formats = append(formats, img.Format())
+	}
+	if strings.Join(formats, " ") != "P6 P1 P5 P3" {
+		t.Errorf("Wrong images: %v", formats)
+	}
+}
+
+func TestStreamWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewStreamWriter(&buf)
+	for _, content := range []string{"P6 1 1 255\n\x01\x02\x03", "P4 3 1\n\xa0", "P2 1 1 7 7"} {
+		img, err := Decode(strings.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write(img); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewStreamReader(&buf)
+	for _, want := range []string{"P6", "P4", "P2"} {
+		img, err := s.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img.Format() != want {
+			t.Errorf("Wrong format: got %s, want %s", img.Format(), want)
+		}
+	}
+	if _, err := s.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}